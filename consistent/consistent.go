@@ -0,0 +1,26 @@
+// consistent proporciona estrategias de hashing consistente para repartir
+// claves entre un conjunto variable de nodos, de modo que al agregar o quitar
+// un nodo sólo cambie de lugar una fracción pequeña de las claves.
+//
+// Se ofrecen dos estrategias detrás de la misma interfaz Locator: un anillo con
+// nodos virtuales (Ring) y hashing por rendezvous o HRW (Rendezvous).
+package consistent
+
+// Locator reparte claves entre nodos.
+type Locator interface {
+	// Add agrega un nodo con el peso dado. Un nodo con peso 2 recibe, en
+	// promedio, el doble de claves que uno con peso 1. Si el nodo ya existe, se
+	// actualiza su peso.
+	Add(node string, weight int)
+	// Remove elimina un nodo. Devuelve false si el nodo no existe.
+	Remove(node string) bool
+	// Locate devuelve el nodo responsable de la clave, o false si no hay nodos.
+	Locate(key string) (string, bool)
+	// LocateN devuelve hasta n nodos distintos responsables de la clave, en
+	// orden de preferencia, para ubicar réplicas.
+	LocateN(key string, n int) []string
+	// Nodes devuelve los nodos, ordenados alfabéticamente.
+	Nodes() []string
+	// Size devuelve la cantidad de nodos.
+	Size() int
+}
@@ -0,0 +1,60 @@
+package consistent
+
+import "fmt"
+
+// Movement resume cuántas claves cambiaron de nodo entre dos asignaciones.
+type Movement struct {
+	// Total es la cantidad de claves comparadas.
+	Total int
+	// Moved es la cantidad de claves que cambiaron de nodo.
+	Moved int
+	// From asocia cada nodo con la cantidad de claves que dejó de tener.
+	From map[string]int
+	// To asocia cada nodo con la cantidad de claves que pasó a tener.
+	To map[string]int
+}
+
+// Assignments devuelve el nodo asignado a cada clave. Las claves sin nodo se
+// asocian a la cadena vacía.
+func Assignments(l Locator, keys []string) map[string]string {
+	result := make(map[string]string, len(keys))
+	for _, key := range keys {
+		result[key], _ = l.Locate(key)
+	}
+	return result
+}
+
+// CompareAssignments compara dos asignaciones de claves a nodos, por ejemplo
+// antes y después de agregar o quitar un nodo.
+//
+// Uso:
+//
+//	before := consistent.Assignments(ring, keys)
+//	ring.Add("worker-4", 1)
+//	report := consistent.CompareAssignments(before, consistent.Assignments(ring, keys))
+func CompareAssignments(before, after map[string]string) Movement {
+	m := Movement{From: make(map[string]int), To: make(map[string]int)}
+	for key, old := range before {
+		m.Total++
+		if current := after[key]; current != old {
+			m.Moved++
+			m.From[old]++
+			m.To[current]++
+		}
+	}
+	return m
+}
+
+// Fraction devuelve la proporción de claves que cambiaron de nodo.
+func (m Movement) Fraction() float64 {
+	if m.Total == 0 {
+		return 0
+	}
+	return float64(m.Moved) / float64(m.Total)
+}
+
+// String devuelve una representación en cadena del resumen.
+func (m Movement) String() string {
+	return fmt.Sprintf("Movement: {moved: %d/%d (%.2f%%), from: %v, to: %v}",
+		m.Moved, m.Total, 100*m.Fraction(), m.From, m.To)
+}
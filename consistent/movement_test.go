@@ -0,0 +1,27 @@
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAssignments(t *testing.T) {
+	before := map[string]string{"a": "w1", "b": "w1", "c": "w2", "d": "w2"}
+	after := map[string]string{"a": "w1", "b": "w3", "c": "w3", "d": "w2"}
+
+	m := CompareAssignments(before, after)
+	assert.Equal(t, 4, m.Total)
+	assert.Equal(t, 2, m.Moved)
+	assert.Equal(t, map[string]int{"w1": 1, "w2": 1}, m.From)
+	assert.Equal(t, map[string]int{"w3": 2}, m.To)
+	assert.Equal(t, 0.5, m.Fraction())
+	assert.Equal(t, "Movement: {moved: 2/4 (50.00%), from: map[w1:1 w2:1], to: map[w3:2]}", m.String())
+}
+
+func TestCompareAssignmentsVacio(t *testing.T) {
+	m := CompareAssignments(nil, nil)
+
+	assert.Equal(t, 0, m.Total)
+	assert.Equal(t, 0.0, m.Fraction())
+}
@@ -0,0 +1,116 @@
+package consistent
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// Rendezvous implementa hashing por rendezvous o HRW (Highest Random Weight):
+// para cada clave se calcula un puntaje con cada nodo y se elige el de mayor
+// puntaje. No requiere nodos virtuales, pero ubicar una clave es O(n) en la
+// cantidad de nodos.
+//
+// Los pesos se aplican con el método logarítmico de Schindelhauer y Schomaker,
+// que mantiene la propiedad de movimiento mínimo al cambiar un peso.
+type Rendezvous struct {
+	// weights asocia cada nodo con su peso.
+	weights map[string]int
+}
+
+var _ Locator = (*Rendezvous)(nil)
+
+// NewRendezvous crea un nuevo conjunto vacío de nodos para hashing por
+// rendezvous.
+func NewRendezvous() *Rendezvous {
+	return &Rendezvous{weights: make(map[string]int)}
+}
+
+// Add agrega un nodo con el peso dado.
+//
+// - Si el peso es menor o igual a 0, se establece en 1.
+//
+// - Si el nodo ya existe, se actualiza su peso.
+func (r *Rendezvous) Add(node string, weight int) {
+	if weight <= 0 {
+		weight = 1
+	}
+	r.weights[node] = weight
+}
+
+// Remove elimina un nodo.
+func (r *Rendezvous) Remove(node string) bool {
+	if _, ok := r.weights[node]; !ok {
+		return false
+	}
+	delete(r.weights, node)
+	return true
+}
+
+// Locate devuelve el nodo de mayor puntaje para la clave.
+func (r *Rendezvous) Locate(key string) (string, bool) {
+	best, bestScore, found := "", math.Inf(-1), false
+	for node, weight := range r.weights {
+		s := score(key, node, weight)
+		if !found || s > bestScore || (s == bestScore && node < best) {
+			best, bestScore, found = node, s, true
+		}
+	}
+	return best, found
+}
+
+// LocateN devuelve hasta n nodos distintos, ordenados de mayor a menor
+// puntaje para la clave.
+func (r *Rendezvous) LocateN(key string, n int) []string {
+	n = min(n, len(r.weights))
+	if n <= 0 {
+		return nil
+	}
+	type scored struct {
+		node  string
+		score float64
+	}
+	all := make([]scored, 0, len(r.weights))
+	for node, weight := range r.weights {
+		all = append(all, scored{node, score(key, node, weight)})
+	}
+	slices.SortFunc(all, func(a, b scored) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.node, b.node)
+	})
+	nodes := make([]string, n)
+	for i := range nodes {
+		nodes[i] = all[i].node
+	}
+	return nodes
+}
+
+// Nodes devuelve los nodos, ordenados alfabéticamente.
+func (r *Rendezvous) Nodes() []string {
+	return sortedNodes(r.weights)
+}
+
+// Size devuelve la cantidad de nodos.
+func (r *Rendezvous) Size() int {
+	return len(r.weights)
+}
+
+// String devuelve una representación en cadena de los nodos.
+func (r *Rendezvous) String() string {
+	return fmt.Sprintf("Rendezvous: {nodes: %v}", r.Nodes())
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// score devuelve el puntaje de un nodo para una clave: -weight / ln(u), donde u
+// es el hash combinado de la clave y el nodo normalizado al intervalo (0, 1).
+func score(key, node string, weight int) float64 {
+	h := hashing.Mix64(hashing.String64(key, 0) ^ hashing.String64(node, 1))
+	u := (float64(h>>11) + 0.5) / (1 << 53)
+	return -float64(weight) / math.Log(u)
+}
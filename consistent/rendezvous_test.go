@@ -0,0 +1,28 @@
+package consistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRendezvous(t *testing.T) {
+	r := NewRendezvous()
+
+	assert.Equal(t, 0, r.Size())
+	assert.Equal(t, "Rendezvous: {nodes: []}", r.String())
+}
+
+func TestRendezvousCambioDePeso(t *testing.T) {
+	r := NewRendezvous()
+	r.Add("w1", 1)
+	r.Add("w2", 1)
+	before := Assignments(r, keys(10000))
+
+	// Al aumentar el peso de un nodo sólo se mueven claves hacia ese nodo.
+	r.Add("w2", 3)
+	m := CompareAssignments(before, Assignments(r, keys(10000)))
+	assert.Equal(t, m.Moved, m.To["w2"])
+	assert.Equal(t, m.Moved, m.From["w1"])
+	assert.InDelta(t, 0.25, m.Fraction(), 0.05)
+}
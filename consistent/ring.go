@@ -0,0 +1,163 @@
+package consistent
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// point es un nodo virtual ubicado en el anillo.
+type point struct {
+	hash uint64
+	node string
+}
+
+// Ring es un anillo de hashing consistente. Cada nodo se ubica en varias
+// posiciones del anillo (nodos virtuales) y cada clave se asigna al primer
+// nodo virtual que la sigue en sentido horario.
+type Ring struct {
+	// virtualNodes es la cantidad de nodos virtuales por unidad de peso.
+	virtualNodes int
+	// points son los nodos virtuales, ordenados por hash.
+	points []point
+	// weights asocia cada nodo con su peso.
+	weights map[string]int
+}
+
+var _ Locator = (*Ring)(nil)
+
+// NewRing crea un nuevo anillo vacío con la cantidad de nodos virtuales por
+// unidad de peso especificada.
+//
+// - Si la cantidad de nodos virtuales es menor o igual a 0, se establece en
+// 160.
+func NewRing(virtualNodes int) *Ring {
+	if virtualNodes <= 0 {
+		virtualNodes = 160
+	}
+	return &Ring{
+		virtualNodes: virtualNodes,
+		weights:      make(map[string]int),
+	}
+}
+
+// Add agrega un nodo al anillo con virtualNodes * weight nodos virtuales.
+//
+// - Si el peso es menor o igual a 0, se establece en 1.
+//
+// - Si el nodo ya existe, se actualiza su peso.
+func (r *Ring) Add(node string, weight int) {
+	if weight <= 0 {
+		weight = 1
+	}
+	if _, ok := r.weights[node]; ok {
+		r.Remove(node)
+	}
+	r.weights[node] = weight
+	for i := range r.virtualNodes * weight {
+		r.points = append(r.points, point{hash: virtualHash(node, i), node: node})
+	}
+	slices.SortFunc(r.points, comparePoints)
+}
+
+// Remove elimina un nodo y todos sus nodos virtuales del anillo.
+func (r *Ring) Remove(node string) bool {
+	if _, ok := r.weights[node]; !ok {
+		return false
+	}
+	delete(r.weights, node)
+	r.points = slices.DeleteFunc(r.points, func(p point) bool {
+		return p.node == node
+	})
+	return true
+}
+
+// Locate devuelve el nodo responsable de la clave.
+func (r *Ring) Locate(key string) (string, bool) {
+	if len(r.points) == 0 {
+		return "", false
+	}
+	return r.points[r.search(hashing.String64(key, 0))].node, true
+}
+
+// LocateN devuelve hasta n nodos distintos, recorriendo el anillo en sentido
+// horario a partir de la clave.
+func (r *Ring) LocateN(key string, n int) []string {
+	n = min(n, len(r.weights))
+	if n <= 0 {
+		return nil
+	}
+	nodes := make([]string, 0, n)
+	start := r.search(hashing.String64(key, 0))
+	for i := 0; i < len(r.points) && len(nodes) < n; i++ {
+		node := r.points[(start+i)%len(r.points)].node
+		if !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Nodes devuelve los nodos del anillo, ordenados alfabéticamente.
+func (r *Ring) Nodes() []string {
+	return sortedNodes(r.weights)
+}
+
+// Size devuelve la cantidad de nodos del anillo.
+func (r *Ring) Size() int {
+	return len(r.weights)
+}
+
+// String devuelve una representación en cadena del anillo.
+func (r *Ring) String() string {
+	return fmt.Sprintf("Ring: {nodes: %v, virtual nodes: %d}", r.Nodes(), len(r.points))
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// search devuelve la posición del primer nodo virtual con hash mayor o igual
+// al dado, volviendo al principio del anillo si no hay ninguno.
+func (r *Ring) search(hash uint64) int {
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= hash
+	})
+	if i == len(r.points) {
+		return 0
+	}
+	return i
+}
+
+// virtualHash devuelve la posición en el anillo del i-ésimo nodo virtual.
+func virtualHash(node string, i int) uint64 {
+	return hashing.String64(node, uint64(i)+1)
+}
+
+// comparePoints ordena nodos virtuales por hash y, ante igual hash, por nodo,
+// para que el anillo no dependa del orden en que se agregaron los nodos.
+func comparePoints(a, b point) int {
+	if a.hash < b.hash {
+		return -1
+	}
+	if a.hash > b.hash {
+		return 1
+	}
+	if a.node < b.node {
+		return -1
+	}
+	if a.node > b.node {
+		return 1
+	}
+	return 0
+}
+
+// sortedNodes devuelve las claves del mapa ordenadas alfabéticamente.
+func sortedNodes(weights map[string]int) []string {
+	nodes := make([]string, 0, len(weights))
+	for node := range weights {
+		nodes = append(nodes, node)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
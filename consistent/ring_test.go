@@ -0,0 +1,127 @@
+package consistent
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// keys devuelve n claves de prueba.
+func keys(n int) []string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = fmt.Sprintf("clave-%d", i)
+	}
+	return result
+}
+
+// locators devuelve una instancia vacía de cada estrategia.
+func locators() map[string]Locator {
+	return map[string]Locator{
+		"ring":       NewRing(0),
+		"rendezvous": NewRendezvous(),
+	}
+}
+
+func TestNewRing(t *testing.T) {
+	r := NewRing(10)
+
+	assert.Equal(t, 0, r.Size())
+	assert.Equal(t, "Ring: {nodes: [], virtual nodes: 0}", r.String())
+}
+
+func TestLocatorVacio(t *testing.T) {
+	for name, l := range locators() {
+		node, ok := l.Locate("hola")
+		assert.False(t, ok, name)
+		assert.Equal(t, "", node, name)
+		assert.Empty(t, l.LocateN("hola", 3), name)
+	}
+}
+
+func TestLocatorAddRemove(t *testing.T) {
+	for name, l := range locators() {
+		l.Add("b", 1)
+		l.Add("a", 1)
+		l.Add("a", 2)
+
+		assert.Equal(t, 2, l.Size(), name)
+		assert.Equal(t, []string{"a", "b"}, l.Nodes(), name)
+		assert.True(t, l.Remove("a"), name)
+		assert.False(t, l.Remove("a"), name)
+
+		node, ok := l.Locate("hola")
+		assert.True(t, ok, name)
+		assert.Equal(t, "b", node, name)
+	}
+}
+
+func TestLocatorEsDeterministico(t *testing.T) {
+	for name := range locators() {
+		a, b := locators()[name], locators()[name]
+		for _, node := range []string{"w1", "w2", "w3"} {
+			a.Add(node, 1)
+		}
+		for _, node := range []string{"w3", "w1", "w2"} {
+			b.Add(node, 1)
+		}
+		assert.Equal(t, Assignments(a, keys(1000)), Assignments(b, keys(1000)), name)
+	}
+}
+
+func TestLocatorReparteSegunPeso(t *testing.T) {
+	for name, l := range locators() {
+		l.Add("w1", 1)
+		l.Add("w2", 1)
+		l.Add("w3", 2)
+
+		count := make(map[string]int)
+		for _, node := range Assignments(l, keys(40000)) {
+			count[node]++
+		}
+		assert.InDelta(t, 10000, count["w1"], 1500, name)
+		assert.InDelta(t, 10000, count["w2"], 1500, name)
+		assert.InDelta(t, 20000, count["w3"], 1500, name)
+	}
+}
+
+func TestLocatorLocateN(t *testing.T) {
+	for name, l := range locators() {
+		for _, node := range []string{"w1", "w2", "w3", "w4"} {
+			l.Add(node, 1)
+		}
+
+		for _, key := range keys(100) {
+			replicas := l.LocateN(key, 3)
+			assert.Len(t, replicas, 3, name)
+			first, _ := l.Locate(key)
+			assert.Equal(t, first, replicas[0], name)
+			assert.NotEqual(t, replicas[0], replicas[1], name)
+			assert.NotEqual(t, replicas[1], replicas[2], name)
+			assert.NotEqual(t, replicas[0], replicas[2], name)
+		}
+		assert.Len(t, l.LocateN("hola", 10), 4, name)
+	}
+}
+
+func TestLocatorMovimientoMinimo(t *testing.T) {
+	for name, l := range locators() {
+		for i := range 4 {
+			l.Add(fmt.Sprintf("w%d", i), 1)
+		}
+		before := Assignments(l, keys(20000))
+
+		// Al agregar un quinto nodo, sólo se mueven las claves que pasa a
+		// tener el nodo nuevo (idealmente 1/5).
+		l.Add("w4", 1)
+		m := CompareAssignments(before, Assignments(l, keys(20000)))
+		assert.InDelta(t, 0.2, m.Fraction(), 0.05, name)
+		assert.Equal(t, m.Moved, m.To["w4"], name)
+
+		// Al quitarlo, vuelven exactamente a su lugar anterior.
+		l.Remove("w4")
+		m = CompareAssignments(before, Assignments(l, keys(20000)))
+		assert.Equal(t, 0, m.Moved, name)
+	}
+}
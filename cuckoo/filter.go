@@ -0,0 +1,352 @@
+// cuckoo proporciona una implementación de un filtro cuckoo: una estructura
+// probabilística para consultas de pertenencia que, a diferencia de un filtro
+// de Bloom, permite eliminar elementos.
+//
+// El filtro almacena una huella (fingerprint) de cada elemento en una tabla
+// cuckoo dividida en buckets de tamaño fijo. Cada elemento tiene dos buckets
+// posibles, y el segundo se calcula a partir del primero y de la huella, por lo
+// que es posible reubicar huellas sin conocer el elemento original.
+package cuckoo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// bucketSize es la cantidad de huellas que entran en cada bucket.
+const bucketSize = 4
+
+// maxKicks es la cantidad máxima de reubicaciones que se intentan antes de
+// considerar que el filtro está lleno.
+const maxKicks = 500
+
+// Semillas utilizadas para calcular el índice y la huella de un elemento.
+const (
+	indexSeed       uint64 = 0x9e3779b97f4a7c15
+	fingerprintSeed uint64 = 0xc2b2ae3d27d4eb4f
+)
+
+// victim guarda una huella que no pudo ser ubicada tras agotar las
+// reubicaciones, para no perder elementos ya insertados.
+type victim struct {
+	index       uint64
+	fingerprint uint16
+	used        bool
+}
+
+// Filter es un filtro cuckoo. Una huella igual a 0 indica una posición vacía.
+type Filter struct {
+	// table contiene las huellas de todos los buckets, de a bucketSize por bucket.
+	table []uint16
+	// numBuckets es la cantidad de buckets, siempre una potencia de dos.
+	numBuckets uint64
+	// fingerprintBits es la cantidad de bits de cada huella.
+	fingerprintBits uint8
+	// count es la cantidad de elementos almacenados.
+	count uint
+	// victim es la huella pendiente de ubicar cuando el filtro se llenó.
+	victim victim
+	// kick es un contador utilizado para elegir qué huella desalojar.
+	kick uint64
+}
+
+// NewFilter crea un nuevo filtro cuckoo con capacidad para la cantidad de
+// elementos especificada.
+//
+// - Si la capacidad es igual a 0, se establece en 1024.
+//
+// - Si la cantidad de bits de la huella es 0 o mayor que 16, se establece en 8.
+//
+// - Si el factor de carga es menor o igual a 0 o mayor que 1, se establece en
+// 0.95.
+//
+// La cantidad de buckets se redondea a la siguiente potencia de dos, de modo
+// que la capacidad real puede ser mayor a la pedida.
+func NewFilter(capacity uint, fingerprintBits uint8, loadFactor float32) *Filter {
+	if capacity == 0 {
+		capacity = 1024
+	}
+	if fingerprintBits == 0 || fingerprintBits > 16 {
+		fingerprintBits = 8
+	}
+	if loadFactor <= 0 || loadFactor > 1 {
+		loadFactor = 0.95
+	}
+	buckets := uint64(math.Ceil(float64(capacity) / (bucketSize * float64(loadFactor))))
+	numBuckets := hashing.NextPowerOfTwo(buckets)
+	return &Filter{
+		table:           make([]uint16, numBuckets*bucketSize),
+		numBuckets:      numBuckets,
+		fingerprintBits: fingerprintBits,
+	}
+}
+
+// Insert agrega un elemento al filtro.
+//
+// Devuelve true si se agregó el elemento, false si el filtro está lleno.
+//
+// - Insertar dos veces el mismo elemento almacena dos huellas, por lo que
+// luego debe eliminarse dos veces.
+func (f *Filter) Insert(item []byte) bool {
+	if f.victim.used {
+		// El filtro está lleno salvo que la víctima pueda reubicarse.
+		if f.reinsertVictim(); f.victim.used {
+			return false
+		}
+	}
+	i1, fp := f.indexAndFingerprint(item)
+	i2 := f.altIndex(i1, fp)
+	if f.insertInto(i1, fp) || f.insertInto(i2, fp) {
+		f.count++
+		return true
+	}
+
+	// Ambos buckets están llenos: desalojamos huellas hasta encontrar lugar.
+	index := i1
+	if f.kick%2 == 1 {
+		index = i2
+	}
+	for range maxKicks {
+		slot := index*bucketSize + f.kick%bucketSize
+		f.kick++
+		fp, f.table[slot] = f.table[slot], fp
+		index = f.altIndex(index, fp)
+		if f.insertInto(index, fp) {
+			f.count++
+			return true
+		}
+	}
+
+	// No se encontró lugar: guardamos la huella desalojada como víctima.
+	f.victim = victim{index: index, fingerprint: fp, used: true}
+	f.count++
+	return true
+}
+
+// Lookup devuelve true si el elemento posiblemente pertenece al filtro, false
+// si seguro no pertenece.
+func (f *Filter) Lookup(item []byte) bool {
+	i1, fp := f.indexAndFingerprint(item)
+	i2 := f.altIndex(i1, fp)
+	if f.victim.used && f.victim.fingerprint == fp &&
+		(f.victim.index == i1 || f.victim.index == i2) {
+		return true
+	}
+	return f.bucketContains(i1, fp) || f.bucketContains(i2, fp)
+}
+
+// Delete elimina una aparición del elemento del filtro.
+//
+// Devuelve true si se eliminó el elemento, false si no se encontró.
+//
+// - Sólo deben eliminarse elementos que fueron insertados previamente; de lo
+// contrario puede eliminarse la huella de otro elemento que coincida.
+func (f *Filter) Delete(item []byte) bool {
+	i1, fp := f.indexAndFingerprint(item)
+	i2 := f.altIndex(i1, fp)
+	if f.deleteFrom(i1, fp) || f.deleteFrom(i2, fp) {
+		f.count--
+		f.reinsertVictim()
+		return true
+	}
+	if f.victim.used && f.victim.fingerprint == fp &&
+		(f.victim.index == i1 || f.victim.index == i2) {
+		f.victim = victim{}
+		f.count--
+		return true
+	}
+	return false
+}
+
+// Count devuelve la cantidad de elementos almacenados en el filtro.
+func (f *Filter) Count() uint {
+	return f.count
+}
+
+// Capacity devuelve la cantidad máxima de huellas que puede almacenar el
+// filtro.
+func (f *Filter) Capacity() uint {
+	return uint(len(f.table))
+}
+
+// LoadFactor devuelve la proporción de posiciones ocupadas del filtro.
+func (f *Filter) LoadFactor() float64 {
+	return float64(f.count) / float64(len(f.table))
+}
+
+// FingerprintBits devuelve la cantidad de bits de cada huella.
+func (f *Filter) FingerprintBits() uint8 {
+	return f.fingerprintBits
+}
+
+// Reset elimina todos los elementos del filtro.
+func (f *Filter) Reset() {
+	clear(f.table)
+	f.count = 0
+	f.victim = victim{}
+}
+
+// String devuelve una representación en cadena del filtro.
+func (f *Filter) String() string {
+	return fmt.Sprintf("Cuckoo: {buckets: %d, fingerprint: %d bits, count: %d}",
+		f.numBuckets, f.fingerprintBits, f.count)
+}
+
+// Serialización ///////////////////////////////////////////////////////////////
+
+// magic identifica el formato binario del filtro.
+var magic = [4]byte{'C', 'K', 'O', 'O'}
+
+// version es la versión del formato binario del filtro.
+const version = 1
+
+// headerSize es el tamaño en bytes del encabezado del formato binario.
+const headerSize = 4 + 1 + 1 + 1 + 8 + 8 + 1 + 8 + 2
+
+// ErrInvalidFormat se devuelve al deserializar datos que no corresponden a un
+// filtro cuckoo válido.
+var ErrInvalidFormat = errors.New("cuckoo: formato inválido")
+
+// MarshalBinary serializa el filtro en un arreglo de bytes.
+//
+// El formato es: magic, versión, bits de huella, tamaño de bucket, cantidad de
+// buckets, cantidad de elementos, víctima (usada, índice, huella) y las huellas
+// de la tabla, todos los enteros en little endian.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, headerSize+2*len(f.table))
+	data = append(data, magic[:]...)
+	data = append(data, version, f.fingerprintBits, bucketSize)
+	data = binary.LittleEndian.AppendUint64(data, f.numBuckets)
+	data = binary.LittleEndian.AppendUint64(data, uint64(f.count))
+	var used byte
+	if f.victim.used {
+		used = 1
+	}
+	data = append(data, used)
+	data = binary.LittleEndian.AppendUint64(data, f.victim.index)
+	data = binary.LittleEndian.AppendUint16(data, f.victim.fingerprint)
+	for _, fp := range f.table {
+		data = binary.LittleEndian.AppendUint16(data, fp)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary reemplaza el contenido del filtro por el serializado en data.
+//
+// Devuelve ErrInvalidFormat si los datos no son válidos.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return ErrInvalidFormat
+	}
+	if data[4] != version {
+		return fmt.Errorf("%w: versión %d no soportada", ErrInvalidFormat, data[4])
+	}
+	fingerprintBits, size := data[5], data[6]
+	if fingerprintBits == 0 || fingerprintBits > 16 || size != bucketSize {
+		return ErrInvalidFormat
+	}
+	// Se acota numBuckets antes de multiplicarlo, para que el producto no
+	// desborde.
+	numBuckets := binary.LittleEndian.Uint64(data[7:])
+	if numBuckets == 0 || numBuckets&(numBuckets-1) != 0 ||
+		numBuckets > uint64(len(data)-headerSize)/(bucketSize*2) ||
+		uint64(len(data)-headerSize) != numBuckets*bucketSize*2 {
+		return ErrInvalidFormat
+	}
+	count := binary.LittleEndian.Uint64(data[15:])
+	v := victim{
+		used:        data[23] == 1,
+		index:       binary.LittleEndian.Uint64(data[24:]),
+		fingerprint: binary.LittleEndian.Uint16(data[32:]),
+	}
+	maxFingerprint := uint16(1<<fingerprintBits - 1)
+	if v.used && (v.index >= numBuckets || v.fingerprint == 0 || v.fingerprint > maxFingerprint) {
+		return ErrInvalidFormat
+	}
+	table := make([]uint16, numBuckets*bucketSize)
+	for i := range table {
+		table[i] = binary.LittleEndian.Uint16(data[headerSize+2*i:])
+		if table[i] > maxFingerprint {
+			return ErrInvalidFormat
+		}
+	}
+	*f = Filter{
+		table:           table,
+		numBuckets:      numBuckets,
+		fingerprintBits: fingerprintBits,
+		count:           uint(count),
+		victim:          v,
+	}
+	return nil
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// indexAndFingerprint devuelve el bucket primario y la huella de un elemento.
+// La huella nunca es 0, ya que ese valor marca posiciones vacías.
+func (f *Filter) indexAndFingerprint(item []byte) (uint64, uint16) {
+	hash := hashing.Sum64(item, indexSeed)
+	index := hash & (f.numBuckets - 1)
+	fp := uint16(hash >> (64 - f.fingerprintBits))
+	if fp == 0 {
+		fp = 1
+	}
+	return index, fp
+}
+
+// altIndex devuelve el bucket alternativo para una huella ubicada en index.
+// La operación es su propia inversa: altIndex(altIndex(i, fp), fp) == i.
+func (f *Filter) altIndex(index uint64, fp uint16) uint64 {
+	return (index ^ hashing.Mix64(uint64(fp)^fingerprintSeed)) & (f.numBuckets - 1)
+}
+
+// insertInto agrega la huella en la primera posición libre del bucket.
+func (f *Filter) insertInto(index uint64, fp uint16) bool {
+	bucket := f.table[index*bucketSize : (index+1)*bucketSize]
+	for i := range bucket {
+		if bucket[i] == 0 {
+			bucket[i] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// deleteFrom elimina una aparición de la huella del bucket.
+func (f *Filter) deleteFrom(index uint64, fp uint16) bool {
+	bucket := f.table[index*bucketSize : (index+1)*bucketSize]
+	for i := range bucket {
+		if bucket[i] == fp {
+			bucket[i] = 0
+			return true
+		}
+	}
+	return false
+}
+
+// bucketContains devuelve true si el bucket contiene la huella.
+func (f *Filter) bucketContains(index uint64, fp uint16) bool {
+	bucket := f.table[index*bucketSize : (index+1)*bucketSize]
+	for _, v := range bucket {
+		if v == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// reinsertVictim intenta ubicar la víctima luego de liberar una posición.
+func (f *Filter) reinsertVictim() {
+	if !f.victim.used {
+		return
+	}
+	v := f.victim
+	if f.insertInto(v.index, v.fingerprint) ||
+		f.insertInto(f.altIndex(v.index, v.fingerprint), v.fingerprint) {
+		f.victim = victim{}
+	}
+}
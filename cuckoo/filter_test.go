@@ -0,0 +1,172 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFilter(t *testing.T) {
+	f := NewFilter(100, 12, 0.9)
+
+	assert.NotNil(t, f)
+	assert.Equal(t, uint(0), f.Count())
+	assert.Equal(t, uint8(12), f.FingerprintBits())
+	assert.GreaterOrEqual(t, f.Capacity(), uint(100))
+}
+
+func TestNewFilterValoresPorDefecto(t *testing.T) {
+	f := NewFilter(0, 0, 0)
+
+	assert.Equal(t, uint8(8), f.FingerprintBits())
+	assert.GreaterOrEqual(t, f.Capacity(), uint(1024))
+}
+
+func TestFilterInsertLookup(t *testing.T) {
+	f := NewFilter(1000, 16, 0.95)
+
+	for i := range 500 {
+		assert.True(t, f.Insert(fmt.Appendf(nil, "elemento-%d", i)))
+	}
+	assert.Equal(t, uint(500), f.Count())
+	for i := range 500 {
+		assert.True(t, f.Lookup(fmt.Appendf(nil, "elemento-%d", i)))
+	}
+}
+
+func TestFilterFalsosPositivos(t *testing.T) {
+	f := NewFilter(10000, 16, 0.95)
+	for i := range 9000 {
+		f.Insert(fmt.Appendf(nil, "presente-%d", i))
+	}
+
+	falsePositives := 0
+	for i := range 10000 {
+		if f.Lookup(fmt.Appendf(nil, "ausente-%d", i)) {
+			falsePositives++
+		}
+	}
+	// Con huellas de 16 bits y buckets de 4, la tasa esperada es ~0.01%.
+	assert.Less(t, falsePositives, 20)
+}
+
+func TestFilterDelete(t *testing.T) {
+	f := NewFilter(100, 16, 0.95)
+	f.Insert([]byte("hola"))
+	f.Insert([]byte("chau"))
+
+	assert.True(t, f.Delete([]byte("hola")))
+	assert.False(t, f.Lookup([]byte("hola")))
+	assert.True(t, f.Lookup([]byte("chau")))
+	assert.Equal(t, uint(1), f.Count())
+	assert.False(t, f.Delete([]byte("hola")))
+}
+
+func TestFilterDeleteDuplicados(t *testing.T) {
+	f := NewFilter(100, 16, 0.95)
+	f.Insert([]byte("hola"))
+	f.Insert([]byte("hola"))
+
+	assert.True(t, f.Delete([]byte("hola")))
+	assert.True(t, f.Lookup([]byte("hola")))
+	assert.True(t, f.Delete([]byte("hola")))
+	assert.False(t, f.Lookup([]byte("hola")))
+}
+
+func TestFilterLleno(t *testing.T) {
+	f := NewFilter(64, 16, 1)
+
+	inserted := 0
+	for i := range 1000 {
+		if !f.Insert(fmt.Appendf(nil, "elemento-%d", i)) {
+			break
+		}
+		inserted++
+	}
+	assert.Less(t, inserted, 1000)
+	assert.LessOrEqual(t, uint(inserted), f.Capacity()+1)
+
+	// Ningún elemento insertado se pierde, aunque el filtro esté lleno.
+	for i := range inserted {
+		assert.True(t, f.Lookup(fmt.Appendf(nil, "elemento-%d", i)))
+	}
+
+	// Al liberar lugar se puede volver a insertar.
+	for i := range inserted / 2 {
+		require.True(t, f.Delete(fmt.Appendf(nil, "elemento-%d", i)))
+	}
+	assert.True(t, f.Insert([]byte("otro")))
+}
+
+func TestFilterReset(t *testing.T) {
+	f := NewFilter(100, 8, 0.95)
+	f.Insert([]byte("hola"))
+
+	f.Reset()
+	assert.Equal(t, uint(0), f.Count())
+	assert.False(t, f.Lookup([]byte("hola")))
+}
+
+func TestFilterMarshalBinary(t *testing.T) {
+	f := NewFilter(1000, 12, 0.9)
+	for i := range 700 {
+		f.Insert(fmt.Appendf(nil, "elemento-%d", i))
+	}
+
+	data, err := f.MarshalBinary()
+	require.NoError(t, err)
+
+	g := &Filter{}
+	require.NoError(t, g.UnmarshalBinary(data))
+	assert.Equal(t, f.Count(), g.Count())
+	assert.Equal(t, f.FingerprintBits(), g.FingerprintBits())
+	assert.Equal(t, f.Capacity(), g.Capacity())
+	for i := range 700 {
+		assert.True(t, g.Lookup(fmt.Appendf(nil, "elemento-%d", i)))
+	}
+	assert.True(t, g.Delete([]byte("elemento-0")))
+}
+
+func TestFilterUnmarshalBinaryInvalido(t *testing.T) {
+	f := NewFilter(16, 8, 0.9)
+	data, _ := f.MarshalBinary()
+
+	assert.ErrorIs(t, (&Filter{}).UnmarshalBinary(nil), ErrInvalidFormat)
+	assert.ErrorIs(t, (&Filter{}).UnmarshalBinary(data[:len(data)-1]), ErrInvalidFormat)
+
+	data[0] = 'X'
+	assert.ErrorIs(t, (&Filter{}).UnmarshalBinary(data), ErrInvalidFormat)
+}
+
+func TestFilterUnmarshalBinaryEncabezadoManipulado(t *testing.T) {
+	f := NewFilter(16, 8, 0.9)
+	data, _ := f.MarshalBinary()
+
+	// Una cantidad de buckets enorme, sin la tabla, no debe desbordar el
+	// cálculo del tamaño esperado.
+	header := slices.Clone(data[:headerSize])
+	binary.LittleEndian.PutUint64(header[7:], 1<<61)
+	assert.ErrorIs(t, (&Filter{}).UnmarshalBinary(header), ErrInvalidFormat)
+
+	// Una víctima con una huella que no entra en los bits de huella.
+	victim := slices.Clone(data)
+	victim[23] = 1
+	binary.LittleEndian.PutUint16(victim[32:], 0x1ff)
+	assert.ErrorIs(t, (&Filter{}).UnmarshalBinary(victim), ErrInvalidFormat)
+
+	// Una huella de la tabla que no entra en los bits de huella.
+	table := slices.Clone(data)
+	binary.LittleEndian.PutUint16(table[headerSize:], 0x100)
+	assert.ErrorIs(t, (&Filter{}).UnmarshalBinary(table), ErrInvalidFormat)
+}
+
+func TestFilterString(t *testing.T) {
+	f := NewFilter(4, 8, 1)
+	f.Insert([]byte("hola"))
+
+	assert.Equal(t, "Cuckoo: {buckets: 1, fingerprint: 8 bits, count: 1}", f.String())
+}
@@ -0,0 +1,227 @@
+package dictionary
+
+import "errors"
+
+// DefaultDictionary es un diccionario que crea el valor de una clave la
+// primera vez que se la consulta, usando una función de fábrica. Evita tener
+// que verificar si la clave existe antes de agregarla, por ejemplo al contar
+// o agrupar.
+//
+// Es seguro para uso concurrente.
+type DefaultDictionary[K comparable, V any] struct {
+	// dict es el contenido del diccionario.
+	dict *Dictionary[K, V]
+	// factory crea el valor de las claves que no existen.
+	factory func() V
+}
+
+// NewDefaultDictionary crea un nuevo diccionario vacío que crea los valores
+// faltantes con la función dada.
+//
+// Uso:
+//
+//	groups := dictionary.NewDefaultDictionary[string](func() []string { return nil })
+//	groups.Update("Ana", func(l *[]string) { *l = append(*l, "Mie 10") })
+//
+// Parámetros:
+//   - `factory`: la función que crea el valor de una clave que no existe. Si
+//     es nil, entra en pánico.
+func NewDefaultDictionary[K comparable, V any](factory func() V) *DefaultDictionary[K, V] {
+	if factory == nil {
+		panic("dictionary: la función de fábrica no puede ser nil")
+	}
+	return &DefaultDictionary[K, V]{dict: NewDictionary[K, V](), factory: factory}
+}
+
+// Get devuelve el valor asociado a la clave. Si la clave no existe, la agrega
+// con el valor creado por la función de fábrica.
+//
+// Uso:
+//
+//	value := dict.Get("uno") // Crea la clave "uno" si no existe.
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave. Si la clave no es válida, el valor creado
+//     por la función de fábrica, sin agregarlo.
+func (d *DefaultDictionary[K, V]) Get(key K) V {
+	defer d.dict.feed.flush()
+	d.dict.mu.Lock()
+	defer d.dict.mu.Unlock()
+	value, err := d.dict.hash.TryGet(key)
+	if err == nil {
+		return value
+	}
+	value = d.factory()
+	if !errors.Is(err, ErrInvalidKey) {
+		d.dict.put(key, value)
+	}
+	return value
+}
+
+// Update modifica el valor asociado a la clave. Si la clave no existe, se
+// modifica el valor creado por la función de fábrica y luego se agrega.
+//
+// La función se llama con el diccionario bloqueado, por lo que no debe usar
+// el diccionario.
+//
+// Uso:
+//
+//	counts := dictionary.NewDefaultDictionary[string](func() int { return 0 })
+//	counts.Update("a", func(n *int) { *n++ }) // Cuenta una aparición de "a".
+//
+// Parámetros:
+//   - `key`: la clave a modificar.
+//   - `fn`: la función que modifica el valor.
+//
+// Retorna:
+//   - `nil` si se modificó el valor; un error que envuelve `ErrInvalidKey` o
+//     `ErrCapacityExceeded` en caso contrario.
+func (d *DefaultDictionary[K, V]) Update(key K, fn func(value *V)) error {
+	defer d.dict.feed.flush()
+	d.dict.mu.Lock()
+	defer d.dict.mu.Unlock()
+	value, err := d.dict.hash.TryGet(key)
+	if errors.Is(err, ErrInvalidKey) {
+		return err
+	}
+	if err != nil {
+		value = d.factory()
+	}
+	fn(&value)
+	return d.dict.put(key, value)
+}
+
+// Put agrega un par clave-valor al diccionario. Si la clave ya existe,
+// reemplaza el valor asociado.
+//
+// Uso:
+//
+//	dict.Put("uno", 1)
+//
+// Parámetros:
+//   - `key`: la clave.
+//   - `value`: el valor a asociar a la clave.
+//
+// Retorna:
+//   - `true` si se agregó o actualizó el par; `false` en caso contrario.
+func (d *DefaultDictionary[K, V]) Put(key K, value V) bool {
+	return d.dict.Put(key, value)
+}
+
+// Contains verifica si el diccionario contiene la clave, sin agregarla.
+//
+// Uso:
+//
+//	exists := dict.Contains("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - `true` si el diccionario contiene la clave; `false` en caso contrario.
+func (d *DefaultDictionary[K, V]) Contains(key K) bool {
+	return d.dict.Contains(key)
+}
+
+// Remove elimina la clave y su valor asociado del diccionario.
+//
+// Uso:
+//
+//	dict.Remove("uno")
+//
+// Parámetros:
+//   - `key`: la clave a eliminar.
+//
+// Retorna:
+//   - `true` si se eliminó la clave; `false` si la clave no existe.
+func (d *DefaultDictionary[K, V]) Remove(key K) bool {
+	return d.dict.Remove(key)
+}
+
+// Keys devuelve las claves del diccionario.
+//
+// Uso:
+//
+//	keys := dict.Keys()
+//
+// Retorna:
+//   - las claves del diccionario como un slice.
+func (d *DefaultDictionary[K, V]) Keys() []K {
+	return d.dict.Keys()
+}
+
+// Values devuelve los valores del diccionario.
+//
+// Uso:
+//
+//	values := dict.Values()
+//
+// Retorna:
+//   - los valores del diccionario como un slice.
+func (d *DefaultDictionary[K, V]) Values() []V {
+	return d.dict.Values()
+}
+
+// Size devuelve la cantidad de claves del diccionario.
+//
+// Uso:
+//
+//	size := dict.Size()
+//
+// Retorna:
+//   - la cantidad de claves del diccionario.
+func (d *DefaultDictionary[K, V]) Size() int {
+	return d.dict.Size()
+}
+
+// IsEmpty evalúa si el diccionario está vacío.
+//
+// Uso:
+//
+//	empty := dict.IsEmpty()
+//
+// Retorna:
+//   - `true` si el diccionario está vacío; `false` en caso contrario.
+func (d *DefaultDictionary[K, V]) IsEmpty() bool {
+	return d.dict.IsEmpty()
+}
+
+// Clear elimina todas las claves del diccionario.
+//
+// Uso:
+//
+//	dict.Clear()
+func (d *DefaultDictionary[K, V]) Clear() {
+	d.dict.Clear()
+}
+
+// Dictionary devuelve el diccionario con el contenido del DefaultDictionary.
+// Ambos comparten el contenido, por lo que los cambios en uno se ven en el
+// otro, pero el Get del diccionario devuelto no agrega claves.
+//
+// Uso:
+//
+//	counts.Dictionary().Subscribe(nil, 0, dictionary.DropNewest)
+//
+// Retorna:
+//   - el diccionario con el contenido del DefaultDictionary.
+func (d *DefaultDictionary[K, V]) Dictionary() *Dictionary[K, V] {
+	return d.dict
+}
+
+// String devuelve una representación en cadena del diccionario.
+//
+// Uso:
+//
+//	fmt.Println(dict)
+//
+// Retorna:
+//   - una representación en cadena del diccionario.
+func (d *DefaultDictionary[K, V]) String() string {
+	d.dict.mu.RLock()
+	defer d.dict.mu.RUnlock()
+	return "DefaultDictionary: " + d.dict.hash.String()
+}
@@ -0,0 +1,107 @@
+package dictionary
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDictionaryGetCreaLaClave(t *testing.T) {
+	calls := 0
+	dict := NewDefaultDictionary[string](func() int {
+		calls++
+		return 7
+	})
+
+	assert.False(t, dict.Contains("uno"))
+	assert.Equal(t, 7, dict.Get("uno"))
+	assert.True(t, dict.Contains("uno"))
+	assert.Equal(t, 7, dict.Get("uno"))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, dict.Size())
+	assert.Equal(t, "DefaultDictionary: {uno: 7}", dict.String())
+}
+
+func TestDefaultDictionaryContar(t *testing.T) {
+	counts := NewDefaultDictionary[string](func() int { return 0 })
+	for _, r := range "hoy es lunes" {
+		if r != ' ' {
+			require.NoError(t, counts.Update(string(r), func(n *int) { *n++ }))
+		}
+	}
+
+	assert.Equal(t, 8, counts.Size())
+	assert.Equal(t, 2, counts.Get("e"))
+	assert.Equal(t, 2, counts.Get("s"))
+	assert.Equal(t, 1, counts.Get("h"))
+}
+
+func TestDefaultDictionaryAgrupar(t *testing.T) {
+	groups := NewDefaultDictionary[string](func() []string { return nil })
+	for _, word := range strings.Fields("ana pedro ale pablo") {
+		groups.Update(word[:1], func(l *[]string) { *l = append(*l, word) })
+	}
+
+	assert.Equal(t, []string{"ana", "ale"}, groups.Get("a"))
+	assert.Equal(t, []string{"pedro", "pablo"}, groups.Get("p"))
+	assert.Empty(t, groups.Get("z"))
+	assert.ElementsMatch(t, []string{"a", "p", "z"}, groups.Keys())
+}
+
+func TestDefaultDictionaryClaveInvalida(t *testing.T) {
+	dict := NewDefaultDictionary[float64](func() int { return 1 })
+
+	assert.Equal(t, 1, dict.Get(math.NaN()))
+	assert.ErrorIs(t, dict.Update(math.NaN(), func(n *int) { *n++ }), ErrInvalidKey)
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestDefaultDictionaryFactoryNil(t *testing.T) {
+	assert.Panics(t, func() { NewDefaultDictionary[string, int](nil) })
+}
+
+func TestDefaultDictionaryComparteContenido(t *testing.T) {
+	counts := NewDefaultDictionary[string](func() int { return 0 })
+	sub := counts.Dictionary().Subscribe(nil, 0, DropNewest)
+	defer counts.Dictionary().Unsubscribe(sub)
+
+	counts.Get("uno")
+	counts.Update("uno", func(n *int) { *n += 5 })
+	assert.Equal(t, 5, counts.Dictionary().Get("uno"))
+	assert.False(t, counts.Dictionary().Contains("dos"))
+
+	assert.Equal(t, []Event[string, int]{
+		{Kind: EventPut, Key: "uno"},
+		{Kind: EventPut, Key: "uno", NewValue: 5, Replaced: true},
+	}, drain(sub))
+
+	assert.True(t, counts.Remove("uno"))
+	counts.Put("dos", 2)
+	assert.Equal(t, []int{2}, counts.Values())
+	counts.Clear()
+	assert.True(t, counts.Dictionary().IsEmpty())
+}
+
+func TestDefaultDictionaryConcurrente(t *testing.T) {
+	counts := NewDefaultDictionary[int](func() int { return 0 })
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				counts.Update(i%10, func(n *int) { *n++ })
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range 10 {
+		assert.Equal(t, 800, counts.Get(i))
+	}
+}
@@ -1,9 +1,364 @@
+// Package dictionary proporciona un diccionario genérico implementado sobre
+// una tabla de hash cerrada.
 package dictionary
 
-import "untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+import (
+	"sync"
 
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// Errores devueltos por las variantes Try de las operaciones. Son los mismos
+// valores que los de hashtable, por lo que errors.Is los reconoce con
+// cualquiera de los dos nombres.
+var (
+	// ErrInvalidKey indica que la clave no puede agregarse al diccionario,
+	// por ejemplo porque no es igual a sí misma (NaN).
+	ErrInvalidKey = hashtable.ErrInvalidKey
+	// ErrNotFound indica que la clave no existe en el diccionario.
+	ErrNotFound = hashtable.ErrNotFound
+	// ErrCapacityExceeded indica que el diccionario alcanzó su capacidad
+	// máxima (ver SetMaxCapacity).
+	ErrCapacityExceeded = hashtable.ErrCapacityExceeded
+	// ErrConcurrentModification indica que el diccionario se modificó
+	// durante una iteración (ver SortedIterator).
+	ErrConcurrentModification = hashtable.ErrConcurrentModification
+)
+
+// Dictionary implementa un diccionario sobre una tabla de hash.
+//
+// Es seguro para uso concurrente. Las copias de un Dictionary comparten su
+// contenido, su lock y sus suscripciones.
 type Dictionary[K comparable, V any] struct {
-	hash hashtable.HashTable[K, V]
+	mu   *sync.RWMutex
+	hash *hashtable.HashTable[K, V]
+	// feed son las suscripciones a los cambios del diccionario.
+	feed *feed[K, V]
+	// versions registra las versiones del diccionario, si se activó el modo
+	// multiversión (ver EnableVersioning).
+	versions *MVCCDictionary[K, V]
+}
+
+// NewDictionary crea un nuevo diccionario vacío.
+//
+// Uso:
+//
+//	dict := dictionary.NewDictionary[string, int]() // Crea un nuevo diccionario vacío.
+func NewDictionary[K comparable, V any]() *Dictionary[K, V] {
+	return newDictionary(hashtable.NewHashTable[K, V](0, 0))
+}
+
+// NewNormalizedDictionary crea un nuevo diccionario vacío con claves string que
+// se comparan luego de normalizarlas según el modo dado (ver
+// hashtable.Normalization).
+//
+// El diccionario conserva la clave tal como se agregó por primera vez, que es
+// la que devuelve Keys.
+//
+// Uso:
+//
+//	dict := dictionary.NewNormalizedDictionary[string](hashtable.FoldCase | hashtable.IgnoreAccents)
+//	dict.Put("Canción", "Song")
+//	dict.Get("CANCION") // Devuelve "Song".
+//
+// Parámetros:
+//   - `mode`: el modo de normalización de las claves.
+func NewNormalizedDictionary[V any](mode hashtable.Normalization) *Dictionary[string, V] {
+	return newDictionary(hashtable.NewNormalizedHashTable[V](0, 0, mode))
+}
+
+// Put agrega un par clave-valor al diccionario. Si la clave ya existe,
+// reemplaza el valor asociado.
+//
+// Uso:
+//
+//	dict.Put("uno", 1) // Asocia el valor 1 a la clave "uno".
+//
+// Parámetros:
+//   - `key`: la clave.
+//   - `value`: el valor a asociar a la clave.
+//
+// Retorna:
+//   - `true` si se agregó o actualizó el par; `false` si la clave no es válida
+//     o el diccionario alcanzó su capacidad máxima.
+func (d *Dictionary[K, V]) Put(key K, value V) bool {
+	return d.TryPut(key, value) == nil
+}
+
+// TryPut agrega un par clave-valor al diccionario. Si la clave ya existe,
+// reemplaza el valor asociado.
+//
+// Uso:
+//
+//	if err := dict.TryPut(key, 1); errors.Is(err, dictionary.ErrInvalidKey) {
+//		fmt.Println("La clave no es válida.")
+//	}
+//
+// Parámetros:
+//   - `key`: la clave.
+//   - `value`: el valor a asociar a la clave.
+//
+// Retorna:
+//   - `nil` si se agregó o actualizó el par; un error que envuelve
+//     `ErrInvalidKey` o `ErrCapacityExceeded` en caso contrario.
+func (d *Dictionary[K, V]) TryPut(key K, value V) error {
+	defer d.feed.flush()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.put(key, value)
+}
+
+// Get devuelve el valor asociado a la clave.
+//
+// Uso:
+//
+//	value := dict.Get("uno") // Obtiene el valor asociado a la clave "uno".
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave; el valor nulo si la clave no existe.
+func (d *Dictionary[K, V]) Get(key K) V {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	value, _ := d.hash.Get(key)
+	return value
+}
+
+// TryGet devuelve el valor asociado a la clave, o un error si la clave no
+// existe.
+//
+// Uso:
+//
+//	value, err := dict.TryGet("uno")
+//	if errors.Is(err, dictionary.ErrNotFound) {
+//		fmt.Println("La clave \"uno\" no existe.")
+//	}
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave y `nil`; el valor nulo y un error que
+//     envuelve `ErrNotFound` o `ErrInvalidKey` si no se encontró.
+func (d *Dictionary[K, V]) TryGet(key K) (V, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.hash.TryGet(key)
+}
+
+// MustGet devuelve el valor asociado a la clave, y entra en pánico si la
+// clave no existe.
+//
+// Uso:
+//
+//	value := dict.MustGet("uno") // Obtiene el valor; entra en pánico si no existe.
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave.
+func (d *Dictionary[K, V]) MustGet(key K) V {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.hash.MustGet(key)
+}
+
+// Contains verifica si el diccionario contiene la clave.
+//
+// Uso:
+//
+//	if dict.Contains("uno") {
+//		fmt.Println("El diccionario contiene la clave \"uno\".")
+//	}
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - `true` si el diccionario contiene la clave; `false` en caso contrario.
+func (d *Dictionary[K, V]) Contains(key K) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.hash.Get(key)
+	return ok
+}
+
+// Remove elimina la clave y su valor asociado del diccionario.
+//
+// Uso:
+//
+//	dict.Remove("uno") // Elimina la clave "uno".
+//
+// Parámetros:
+//   - `key`: la clave a eliminar.
+//
+// Retorna:
+//   - `true` si se eliminó la clave; `false` si la clave no existe.
+func (d *Dictionary[K, V]) Remove(key K) bool {
+	return d.TryRemove(key) == nil
 }
 
-// Implementar
+// TryRemove elimina la clave y su valor asociado del diccionario, o devuelve
+// un error si la clave no existe.
+//
+// Uso:
+//
+//	err := dict.TryRemove("uno") // Elimina la clave "uno".
+//
+// Parámetros:
+//   - `key`: la clave a eliminar.
+//
+// Retorna:
+//   - `nil` si se eliminó la clave; un error que envuelve `ErrNotFound` o
+//     `ErrInvalidKey` en caso contrario.
+func (d *Dictionary[K, V]) TryRemove(key K) error {
+	defer d.feed.flush()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.feed.active() {
+		if err := d.hash.TryRemove(key); err != nil {
+			return err
+		}
+		d.recordRemove(key)
+		return nil
+	}
+	old, err := d.hash.TryGet(key)
+	if err != nil {
+		return err
+	}
+	d.hash.Remove(key)
+	d.recordRemove(key)
+	d.feed.publish(Event[K, V]{Kind: EventRemove, Key: key, OldValue: old})
+	return nil
+}
+
+// Keys devuelve las claves del diccionario.
+//
+// Uso:
+//
+//	keys := dict.Keys() // Obtiene las claves del diccionario como un slice.
+//
+// Retorna:
+//   - las claves del diccionario como un slice.
+func (d *Dictionary[K, V]) Keys() []K {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.hash.Keys()
+}
+
+// Values devuelve los valores del diccionario.
+//
+// Uso:
+//
+//	values := dict.Values() // Obtiene los valores del diccionario como un slice.
+//
+// Retorna:
+//   - los valores del diccionario como un slice.
+func (d *Dictionary[K, V]) Values() []V {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.hash.Values()
+}
+
+// Size devuelve la cantidad de claves del diccionario.
+//
+// Uso:
+//
+//	size := dict.Size() // Obtiene la cantidad de claves del diccionario.
+//
+// Retorna:
+//   - la cantidad de claves del diccionario.
+func (d *Dictionary[K, V]) Size() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return int(d.hash.Size())
+}
+
+// IsEmpty evalúa si el diccionario está vacío.
+//
+// Uso:
+//
+//	empty := dict.IsEmpty() // Verifica si el diccionario está vacío.
+//
+// Retorna:
+//   - `true` si el diccionario está vacío; `false` en caso contrario.
+func (d *Dictionary[K, V]) IsEmpty() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.hash.IsEmpty()
+}
+
+// SetMaxCapacity establece la capacidad máxima de la tabla de hash del
+// diccionario. Si es 0, no tiene límite.
+//
+// Uso:
+//
+//	dict.SetMaxCapacity(1000) // Limita la tabla a 1000 posiciones.
+//
+// Parámetros:
+//   - `n`: la capacidad máxima.
+func (d *Dictionary[K, V]) SetMaxCapacity(n uint) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.hash.SetMaxCapacity(n)
+}
+
+// Clear elimina todas las claves del diccionario.
+//
+// Uso:
+//
+//	dict.Clear() // Elimina todas las claves del diccionario.
+func (d *Dictionary[K, V]) Clear() {
+	defer d.feed.flush()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.hash.Clear()
+	d.recordClear()
+	if d.feed.active() {
+		d.feed.publish(Event[K, V]{Kind: EventClear})
+	}
+}
+
+// String devuelve una representación en cadena del diccionario.
+//
+// Uso:
+//
+//	fmt.Println(dict) // Muestra el diccionario como una cadena.
+//
+// Retorna:
+//   - una representación en cadena del diccionario.
+func (d *Dictionary[K, V]) String() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return "Dictionary: " + d.hash.String()
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// newDictionary crea un diccionario sobre la tabla dada.
+func newDictionary[K comparable, V any](hash *hashtable.HashTable[K, V]) *Dictionary[K, V] {
+	return &Dictionary[K, V]{mu: &sync.RWMutex{}, hash: hash, feed: &feed[K, V]{}}
+}
+
+// put agrega el par clave-valor, registra la versión y publica el evento
+// correspondiente. Debe llamarse con el lock de escritura tomado, y luego de
+// liberarlo debe llamarse a d.feed.flush para entregar el evento.
+func (d *Dictionary[K, V]) put(key K, value V) error {
+	if !d.feed.active() {
+		if err := d.hash.TryPut(key, value); err != nil {
+			return err
+		}
+		d.recordPut(key, value)
+		return nil
+	}
+	old, getErr := d.hash.TryGet(key)
+	if err := d.hash.TryPut(key, value); err != nil {
+		return err
+	}
+	d.recordPut(key, value)
+	d.feed.publish(Event[K, V]{Kind: EventPut, Key: key, OldValue: old, NewValue: value, Replaced: getErr == nil})
+	return nil
+}
@@ -0,0 +1,147 @@
+package dictionary
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+func TestNewDictionary(t *testing.T) {
+	dict := NewDictionary[string, int]()
+
+	assert.NotNil(t, dict)
+	assert.Equal(t, 0, dict.Size())
+	assert.True(t, dict.IsEmpty())
+	assert.Equal(t, "Dictionary: {}", dict.String())
+}
+
+func TestDictionaryPutGet(t *testing.T) {
+	dict := NewDictionary[string, int]()
+
+	assert.True(t, dict.Put("uno", 1))
+	assert.True(t, dict.Put("dos", 2))
+	assert.True(t, dict.Put("uno", 10))
+	assert.True(t, dict.Put("", 0))
+
+	assert.Equal(t, 3, dict.Size())
+	assert.Equal(t, 10, dict.Get("uno"))
+	assert.Equal(t, 2, dict.Get("dos"))
+	assert.Equal(t, 0, dict.Get("tres"))
+	assert.True(t, dict.Contains("uno"))
+	assert.True(t, dict.Contains(""))
+	assert.False(t, dict.Contains("tres"))
+}
+
+func TestDictionaryClavesNoString(t *testing.T) {
+	dict := NewDictionary[int, string]()
+	for i := 1; i <= 100; i++ {
+		dict.Put(i, "valor")
+	}
+
+	assert.Equal(t, 100, dict.Size())
+	assert.True(t, dict.Contains(50))
+	assert.False(t, dict.Contains(0))
+}
+
+func TestDictionaryRemove(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	assert.True(t, dict.Remove("uno"))
+	assert.False(t, dict.Remove("uno"))
+	assert.False(t, dict.Contains("uno"))
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestDictionaryKeysValues(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.Put("dos", 2)
+
+	assert.ElementsMatch(t, []string{"uno", "dos"}, dict.Keys())
+	assert.ElementsMatch(t, []int{1, 2}, dict.Values())
+}
+
+func TestDictionaryClear(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	dict.Clear()
+	assert.True(t, dict.IsEmpty())
+	assert.False(t, dict.Contains("uno"))
+}
+
+func TestDictionaryString(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	assert.Equal(t, "Dictionary: {uno: 1}", dict.String())
+}
+
+func TestNormalizedDictionary(t *testing.T) {
+	dict := NewNormalizedDictionary[string](hashtable.FoldCase | hashtable.IgnoreAccents)
+	dict.Put("Canción", "Song")
+	dict.Put("CANCIÓN", "Tune")
+
+	assert.Equal(t, 1, dict.Size())
+	assert.Equal(t, "Tune", dict.Get("cancion"))
+	assert.Equal(t, "Tune", dict.Get("Canción"))
+	assert.True(t, dict.Contains("CANCION"))
+	assert.Equal(t, []string{"Canción"}, dict.Keys())
+	assert.True(t, dict.Remove("canción"))
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestDictionaryTryGet(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	v, err := dict.TryGet("uno")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	_, err = dict.TryGet("dos")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, hashtable.ErrNotFound)
+	assert.NotErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestDictionaryMustGet(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	assert.Equal(t, 1, dict.MustGet("uno"))
+	assert.Panics(t, func() { dict.MustGet("dos") })
+}
+
+func TestDictionaryTryPutClaveInvalida(t *testing.T) {
+	dict := NewDictionary[float64, string]()
+
+	assert.ErrorIs(t, dict.TryPut(math.NaN(), "nan"), ErrInvalidKey)
+	assert.False(t, dict.Put(math.NaN(), "nan"))
+	assert.NoError(t, dict.TryPut(1.5, "uno y medio"))
+	assert.Equal(t, 1, dict.Size())
+}
+
+func TestDictionaryTryRemove(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	assert.NoError(t, dict.TryRemove("uno"))
+	assert.ErrorIs(t, dict.TryRemove("uno"), ErrNotFound)
+}
+
+func TestDictionaryMaxCapacity(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	dict.SetMaxCapacity(17)
+
+	var err error
+	for i := 0; err == nil; i++ {
+		err = dict.TryPut(i, i)
+	}
+	assert.ErrorIs(t, err, ErrCapacityExceeded)
+	assert.Equal(t, 12, dict.Size())
+}
@@ -0,0 +1,432 @@
+package dictionary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Nombres de los archivos de un diccionario durable dentro de su directorio.
+const (
+	walFile      = "wal"
+	snapshotFile = "snapshot"
+)
+
+// Operaciones registradas en el log.
+const (
+	opPut byte = iota + 1
+	opRemove
+	opClear
+)
+
+// walHeaderSize es el tamaño del encabezado de cada registro del log: longitud
+// del contenido (4 bytes) y su checksum CRC-32 (4 bytes).
+const walHeaderSize = 8
+
+// snapshotMagic identifica el formato del archivo de snapshot.
+var snapshotMagic = [4]byte{'D', 'S', 'N', 'P'}
+
+// ErrCorruptedSnapshot se devuelve al abrir un diccionario cuyo snapshot no es
+// válido. A diferencia del log, un snapshot dañado no puede recuperarse.
+var ErrCorruptedSnapshot = errors.New("dictionary: snapshot corrupto")
+
+// walEntry es una operación registrada en el log.
+type walEntry[K comparable, V any] struct {
+	Op    byte
+	Key   K
+	Value V
+}
+
+// logFile es el archivo del log. Lo implementa *os.File; es una interfaz para
+// poder simular fallas de escritura en las pruebas.
+type logFile interface {
+	io.Writer
+	io.Seeker
+	io.Closer
+	Sync() error
+	Truncate(size int64) error
+}
+
+// snapshot es el contenido completo del diccionario en un momento dado.
+type snapshot[K comparable, V any] struct {
+	Keys   []K
+	Values []V
+}
+
+// DurableDictionary es un diccionario cuyas modificaciones se registran en un
+// log de escritura anticipada (write-ahead log) antes de aplicarse, de modo
+// que sobreviven a una caída del proceso.
+//
+// Al abrirlo se carga el último snapshot y se reaplican las operaciones del
+// log. Un registro del log escrito a medias al momento de la caída se detecta
+// con su checksum y se descarta, junto con todo lo que le sigue.
+//
+// Las claves y los valores se codifican con encoding/gob, por lo que sus tipos
+// deben ser codificables.
+//
+// Es seguro usarlo desde varias goroutines: las modificaciones se serializan,
+// de modo que el orden de los registros del log coincide con el orden en que
+// se aplican.
+type DurableDictionary[K comparable, V any] struct {
+	// mu serializa las modificaciones: el registro en el log y su aplicación
+	// ocurren sin que se intercale otra modificación.
+	mu sync.Mutex
+	// dict es el contenido actual del diccionario.
+	dict *Dictionary[K, V]
+	// dir es el directorio que contiene el log y el snapshot.
+	dir string
+	// wal es el archivo del log, abierto para agregar registros.
+	wal logFile
+	// entries es la cantidad de registros del log desde el último snapshot.
+	entries int
+	// snapshotEvery es la cantidad de registros a partir de la cual se toma
+	// un snapshot automáticamente.
+	snapshotEvery int
+}
+
+// OpenDurable abre el diccionario durable almacenado en el directorio dado,
+// creándolo si no existe.
+//
+// Cada snapshotEvery operaciones se toma automáticamente un snapshot y se
+// trunca el log.
+//
+// - Si snapshotEvery es igual a 0, se establece en 1000.
+//
+// - Si snapshotEvery es negativo, no se toman snapshots automáticamente.
+func OpenDurable[K comparable, V any](dir string, snapshotEvery int) (*DurableDictionary[K, V], error) {
+	if snapshotEvery == 0 {
+		snapshotEvery = 1000
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	d := &DurableDictionary[K, V]{
+		dict:          NewDictionary[K, V](),
+		dir:           dir,
+		snapshotEvery: snapshotEvery,
+	}
+	if err := d.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := d.replay(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Put agrega un par clave-valor al diccionario. Si la clave ya existe,
+// reemplaza el valor asociado.
+//
+// La operación se registra y se sincroniza con el disco antes de aplicarse.
+// Si la clave no es válida, no se registra nada y se devuelve un error que
+// envuelve ErrInvalidKey.
+func (d *DurableDictionary[K, V]) Put(key K, value V) (bool, error) {
+	if key != key {
+		return false, fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.append(walEntry[K, V]{Op: opPut, Key: key, Value: value}); err != nil {
+		return false, err
+	}
+	d.dict.Put(key, value)
+	return true, d.maybeSnapshot()
+}
+
+// Remove elimina la clave y su valor asociado del diccionario.
+//
+// Devuelve false si la clave no existe, en cuyo caso no se registra nada.
+func (d *DurableDictionary[K, V]) Remove(key K) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.dict.Contains(key) {
+		return false, nil
+	}
+	if err := d.append(walEntry[K, V]{Op: opRemove, Key: key}); err != nil {
+		return false, err
+	}
+	d.dict.Remove(key)
+	return true, d.maybeSnapshot()
+}
+
+// Clear elimina todas las claves del diccionario.
+func (d *DurableDictionary[K, V]) Clear() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.append(walEntry[K, V]{Op: opClear}); err != nil {
+		return err
+	}
+	d.dict.Clear()
+	return d.maybeSnapshot()
+}
+
+// Get devuelve el valor asociado a la clave, o el valor nulo si no existe.
+func (d *DurableDictionary[K, V]) Get(key K) V {
+	return d.dict.Get(key)
+}
+
+// TryGet devuelve el valor asociado a la clave, o un error que envuelve
+// ErrNotFound si no existe.
+func (d *DurableDictionary[K, V]) TryGet(key K) (V, error) {
+	return d.dict.TryGet(key)
+}
+
+// Contains verifica si el diccionario contiene la clave.
+func (d *DurableDictionary[K, V]) Contains(key K) bool {
+	return d.dict.Contains(key)
+}
+
+// Keys devuelve las claves del diccionario.
+func (d *DurableDictionary[K, V]) Keys() []K {
+	return d.dict.Keys()
+}
+
+// Values devuelve los valores del diccionario.
+func (d *DurableDictionary[K, V]) Values() []V {
+	return d.dict.Values()
+}
+
+// Size devuelve la cantidad de claves del diccionario.
+func (d *DurableDictionary[K, V]) Size() int {
+	return d.dict.Size()
+}
+
+// IsEmpty evalúa si el diccionario está vacío.
+func (d *DurableDictionary[K, V]) IsEmpty() bool {
+	return d.dict.IsEmpty()
+}
+
+// String devuelve una representación en cadena del diccionario.
+func (d *DurableDictionary[K, V]) String() string {
+	return d.dict.String()
+}
+
+// Snapshot guarda el contenido completo del diccionario y trunca el log.
+//
+// El snapshot se escribe en un archivo temporal que luego se renombra, por lo
+// que una caída durante la escritura conserva el snapshot anterior. Si la
+// caída ocurre después de renombrarlo pero antes de truncar el log, al abrir
+// el diccionario se reaplica el log sobre el nuevo snapshot, lo que produce
+// el mismo contenido.
+func (d *DurableDictionary[K, V]) Snapshot() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.takeSnapshot()
+}
+
+// Close cierra el log del diccionario.
+func (d *DurableDictionary[K, V]) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.wal.Close()
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// takeSnapshot es como Snapshot, pero debe llamarse con mu tomado.
+func (d *DurableDictionary[K, V]) takeSnapshot() error {
+	snap := snapshot[K, V]{Keys: d.dict.Keys(), Values: make([]V, 0, d.dict.Size())}
+	for _, key := range snap.Keys {
+		snap.Values = append(snap.Values, d.dict.Get(key))
+	}
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(snap); err != nil {
+		return err
+	}
+	data := append([]byte{}, snapshotMagic[:]...)
+	data = binary.LittleEndian.AppendUint32(data, crc32.ChecksumIEEE(payload.Bytes()))
+	data = append(data, payload.Bytes()...)
+
+	path := filepath.Join(d.dir, snapshotFile)
+	if err := writeFileSync(path+".tmp", data); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	if err := syncDir(d.dir); err != nil {
+		return err
+	}
+
+	if err := d.wal.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := d.wal.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	d.entries = 0
+	return d.wal.Sync()
+}
+
+// append agrega una operación al log y la sincroniza con el disco.
+func (d *DurableDictionary[K, V]) append(entry walEntry[K, V]) error {
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(entry); err != nil {
+		return err
+	}
+	record := make([]byte, 0, walHeaderSize+payload.Len())
+	record = binary.LittleEndian.AppendUint32(record, uint32(payload.Len()))
+	record = binary.LittleEndian.AppendUint32(record, crc32.ChecksumIEEE(payload.Bytes()))
+	record = append(record, payload.Bytes()...)
+
+	// Si la escritura o la sincronización fallan, el log se trunca donde
+	// comenzaba el registro. Un registro escrito a medias haría que, al abrir
+	// el diccionario, se descarten todos los registros posteriores, y uno
+	// escrito completo registraría una operación que no se aplicó.
+	offset, err := d.wal.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	_, err = d.wal.Write(record)
+	if err == nil {
+		err = d.wal.Sync()
+	}
+	if err != nil {
+		return errors.Join(err, d.rollback(offset))
+	}
+	d.entries++
+	return nil
+}
+
+// rollback trunca el log en offset y se posiciona allí.
+func (d *DurableDictionary[K, V]) rollback(offset int64) error {
+	if err := d.wal.Truncate(offset); err != nil {
+		return err
+	}
+	_, err := d.wal.Seek(offset, io.SeekStart)
+	return err
+}
+
+// maybeSnapshot toma un snapshot si el log alcanzó la cantidad configurada de
+// registros.
+func (d *DurableDictionary[K, V]) maybeSnapshot() error {
+	if d.snapshotEvery > 0 && d.entries >= d.snapshotEvery {
+		return d.takeSnapshot()
+	}
+	return nil
+}
+
+// loadSnapshot carga el snapshot, si existe.
+func (d *DurableDictionary[K, V]) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(d.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) < 8 || [4]byte(data[:4]) != snapshotMagic ||
+		crc32.ChecksumIEEE(data[8:]) != binary.LittleEndian.Uint32(data[4:]) {
+		return ErrCorruptedSnapshot
+	}
+	var snap snapshot[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data[8:])).Decode(&snap); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorruptedSnapshot, err)
+	}
+	if len(snap.Keys) != len(snap.Values) {
+		return ErrCorruptedSnapshot
+	}
+	for i, key := range snap.Keys {
+		d.dict.Put(key, snap.Values[i])
+	}
+	return nil
+}
+
+// replay reaplica las operaciones del log y lo deja abierto para agregar
+// registros. Si encuentra un registro incompleto o dañado, trunca el log en
+// ese punto.
+func (d *DurableDictionary[K, V]) replay() error {
+	wal, err := os.OpenFile(filepath.Join(d.dir, walFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(wal)
+	if err != nil {
+		wal.Close()
+		return err
+	}
+
+	valid := 0
+	for {
+		entry, size, ok := decodeWalEntry[K, V](data[valid:])
+		if !ok {
+			break
+		}
+		switch entry.Op {
+		case opPut:
+			d.dict.Put(entry.Key, entry.Value)
+		case opRemove:
+			d.dict.Remove(entry.Key)
+		case opClear:
+			d.dict.Clear()
+		}
+		valid += size
+		d.entries++
+	}
+
+	if valid < len(data) {
+		if err := wal.Truncate(int64(valid)); err != nil {
+			wal.Close()
+			return err
+		}
+	}
+	if _, err := wal.Seek(int64(valid), io.SeekStart); err != nil {
+		wal.Close()
+		return err
+	}
+	d.wal = wal
+	return nil
+}
+
+// decodeWalEntry decodifica el primer registro de data. Devuelve false si el
+// registro está incompleto o dañado.
+func decodeWalEntry[K comparable, V any](data []byte) (walEntry[K, V], int, bool) {
+	var entry walEntry[K, V]
+	if len(data) < walHeaderSize {
+		return entry, 0, false
+	}
+	length := int(binary.LittleEndian.Uint32(data))
+	if len(data)-walHeaderSize < length {
+		return entry, 0, false
+	}
+	payload := data[walHeaderSize : walHeaderSize+length]
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(data[4:]) {
+		return entry, 0, false
+	}
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&entry); err != nil {
+		return entry, 0, false
+	}
+	if entry.Op < opPut || entry.Op > opClear {
+		return entry, 0, false
+	}
+	return entry, walHeaderSize + length, true
+}
+
+// writeFileSync escribe un archivo y lo sincroniza con el disco.
+func writeFileSync(path string, data []byte) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return errors.Join(file.Sync(), file.Close())
+}
+
+// syncDir sincroniza un directorio, para que un renombrado sea persistente.
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	return errors.Join(file.Sync(), file.Close())
+}
@@ -0,0 +1,353 @@
+package dictionary
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contents devuelve el contenido de un diccionario durable como un mapa.
+func contents[K comparable, V any](d *DurableDictionary[K, V]) map[K]V {
+	result := make(map[K]V)
+	for _, key := range d.Keys() {
+		result[key] = d.Get(key)
+	}
+	return result
+}
+
+func TestOpenDurableVacio(t *testing.T) {
+	d, err := OpenDurable[string, int](t.TempDir(), 0)
+	require.NoError(t, err)
+	defer d.Close()
+
+	assert.True(t, d.IsEmpty())
+}
+
+func TestDurableReabre(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, []string](dir, -1)
+	require.NoError(t, err)
+
+	_, err = d.Put("Mie 10", []string{"Ana", "Pedro"})
+	require.NoError(t, err)
+	_, err = d.Put("Vie 12", []string{"Ana"})
+	require.NoError(t, err)
+	_, err = d.Put("Mie 17", []string{"Luz"})
+	require.NoError(t, err)
+	ok, err := d.Remove("Mie 17")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = d.Remove("Lun 1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = d.Put("", []string{"Sin fecha"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.NoError(t, d.Close())
+
+	d, err = OpenDurable[string, []string](dir, -1)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, 3, d.Size())
+	assert.Equal(t, []string{"Ana", "Pedro"}, d.Get("Mie 10"))
+	assert.Equal(t, []string{"Sin fecha"}, d.Get(""))
+	assert.Equal(t, []string{"Ana"}, d.Get("Vie 12"))
+	assert.False(t, d.Contains("Mie 17"))
+}
+
+func TestDurableClaveInvalida(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[float64, int](dir, -1)
+	require.NoError(t, err)
+
+	ok, err := d.Put(math.NaN(), 1)
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	require.NoError(t, d.Close())
+
+	info, err := os.Stat(filepath.Join(dir, walFile))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), info.Size(), "no se registra nada en el log")
+}
+
+func TestDurableTryGet(t *testing.T) {
+	d, err := OpenDurable[string, int](t.TempDir(), -1)
+	require.NoError(t, err)
+	defer d.Close()
+	d.Put("uno", 1)
+
+	v, err := d.TryGet("uno")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	_, err = d.TryGet("dos")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDurableClear(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	d.Put("a", 1)
+	require.NoError(t, d.Clear())
+	d.Put("b", 2)
+	require.NoError(t, d.Close())
+
+	d, err = OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, map[string]int{"b": 2}, contents(d))
+}
+
+func TestDurableSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	for i := range 10 {
+		d.Put(fmt.Sprint(i), i)
+	}
+	require.NoError(t, d.Snapshot())
+
+	info, err := os.Stat(filepath.Join(dir, walFile))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), info.Size())
+
+	d.Remove("0")
+	d.Put("1", 100)
+	require.NoError(t, d.Close())
+
+	d, err = OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, 9, d.Size())
+	assert.False(t, d.Contains("0"))
+	assert.Equal(t, 100, d.Get("1"))
+}
+
+func TestDurableSnapshotAutomatico(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, int](dir, 5)
+	require.NoError(t, err)
+	for i := range 12 {
+		d.Put(fmt.Sprint(i), i)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, snapshotFile))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, d.entries)
+	require.NoError(t, d.Close())
+
+	d, err = OpenDurable[string, int](dir, 5)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, 12, d.Size())
+}
+
+func TestDurableSnapshotSinTruncarLog(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	d.Put("a", 1)
+	d.Put("b", 2)
+	d.Remove("a")
+	wal, err := os.ReadFile(filepath.Join(dir, walFile))
+	require.NoError(t, err)
+	require.NoError(t, d.Snapshot())
+	require.NoError(t, d.Close())
+
+	// Simulamos una caída entre el renombrado del snapshot y el truncado del
+	// log: reaplicar el log sobre el snapshot produce el mismo contenido.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, walFile), wal, 0o644))
+
+	d, err = OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, map[string]int{"b": 2}, contents(d))
+}
+
+func TestDurableSnapshotCorrupto(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, snapshotFile), []byte("basura"), 0o644))
+
+	_, err := OpenDurable[string, int](dir, -1)
+	assert.ErrorIs(t, err, ErrCorruptedSnapshot)
+}
+
+// faultyLog es un log que puede fallar al escribir, dejando escrita solo la
+// mitad del registro, o al sincronizar.
+type faultyLog struct {
+	*os.File
+	failWrite, failSync bool
+}
+
+func (f *faultyLog) Write(p []byte) (int, error) {
+	if f.failWrite {
+		n, _ := f.File.Write(p[:len(p)/2])
+		return n, errors.New("escritura incompleta")
+	}
+	return f.File.Write(p)
+}
+
+func (f *faultyLog) Sync() error {
+	if f.failSync {
+		return errors.New("sincronización fallida")
+	}
+	return f.File.Sync()
+}
+
+func TestDurableFallaAlEscribirElLog(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		failWrite, failSync bool
+	}{
+		{"escritura incompleta", true, false},
+		{"sincronización fallida", false, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			d, err := OpenDurable[string, int](dir, -1)
+			require.NoError(t, err)
+			log := &faultyLog{File: d.wal.(*os.File)}
+			d.wal = log
+			d.Put("a", 1)
+
+			log.failWrite, log.failSync = tc.failWrite, tc.failSync
+			_, err = d.Put("b", 2)
+			assert.Error(t, err)
+			_, err = d.Remove("a")
+			assert.Error(t, err)
+			assert.Equal(t, map[string]int{"a": 1}, contents(d))
+			assert.Equal(t, 1, d.entries)
+
+			// Las operaciones posteriores a la falla no se pierden al reabrir.
+			log.failWrite, log.failSync = false, false
+			d.Put("c", 3)
+			require.NoError(t, d.Close())
+
+			d, err = OpenDurable[string, int](dir, -1)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int{"a": 1, "c": 3}, contents(d))
+			require.NoError(t, d.Close())
+		})
+	}
+}
+
+// TestDurableEscriturasInterrumpidas trunca el log en cada posición posible,
+// simulando una caída durante la escritura de un registro, y comprueba que al
+// abrir el diccionario se recuperan exactamente las operaciones completas.
+func TestDurableEscriturasInterrumpidas(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+
+	// expected[i] es el contenido luego de las operaciones completas que
+	// terminan antes de la posición boundaries[i] del log.
+	boundaries := []int64{0}
+	expected := []map[string]int{{}}
+	apply := func(op func()) {
+		op()
+		info, err := d.wal.(*os.File).Stat()
+		require.NoError(t, err)
+		boundaries = append(boundaries, info.Size())
+		expected = append(expected, contents(d))
+	}
+	apply(func() { d.Put("a", 1) })
+	apply(func() { d.Put("b", 2) })
+	apply(func() { d.Put("a", 3) })
+	apply(func() { d.Remove("b") })
+	apply(func() { d.Clear() })
+	apply(func() { d.Put("c", 4) })
+	require.NoError(t, d.Close())
+
+	wal, err := os.ReadFile(filepath.Join(dir, walFile))
+	require.NoError(t, err)
+
+	for offset := int64(0); offset <= int64(len(wal)); offset++ {
+		crashDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(crashDir, walFile), wal[:offset], 0o644))
+
+		d, err := OpenDurable[string, int](crashDir, -1)
+		require.NoError(t, err)
+
+		complete := 0
+		for i, b := range boundaries {
+			if b <= offset {
+				complete = i
+			}
+		}
+		assert.Equal(t, expected[complete], contents(d), "truncado en %d", offset)
+
+		// El registro incompleto se descarta y el log sigue siendo utilizable.
+		info, err := d.wal.(*os.File).Stat()
+		require.NoError(t, err)
+		assert.Equal(t, boundaries[complete], info.Size())
+		_, err = d.Put("z", 9)
+		require.NoError(t, err)
+		require.NoError(t, d.Close())
+
+		d, err = OpenDurable[string, int](crashDir, -1)
+		require.NoError(t, err)
+		assert.Equal(t, 9, d.Get("z"))
+		require.NoError(t, d.Close())
+	}
+}
+
+func TestDurableEscriturasConcurrentes(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, int](dir, 50)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				key := fmt.Sprintf("%d-%d", w, i)
+				_, err := d.Put(key, i)
+				assert.NoError(t, err)
+				if i%3 == 0 {
+					_, err = d.Remove(key)
+					assert.NoError(t, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	want := contents(d)
+	assert.Len(t, want, 8*66)
+	require.NoError(t, d.Close())
+
+	// El log refleja el mismo orden en que se aplicaron las operaciones.
+	d, err = OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, want, contents(d))
+}
+
+func TestDurableRegistroDanado(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	d.Put("a", 1)
+	d.Put("b", 2)
+	require.NoError(t, d.Close())
+
+	// Dañamos el último byte del log: el último registro se descarta.
+	path := filepath.Join(dir, walFile)
+	wal, err := os.ReadFile(path)
+	require.NoError(t, err)
+	wal[len(wal)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(path, wal, 0o644))
+
+	d, err = OpenDurable[string, int](dir, -1)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, map[string]int{"a": 1}, contents(d))
+}
@@ -0,0 +1,327 @@
+package dictionary
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultBufferSize es la cantidad de eventos que se almacenan por suscripción
+// cuando no se indica otra.
+const DefaultBufferSize = 64
+
+// ErrSubscriberOverflow indica que una suscripción con la política Disconnect
+// se canceló porque su buffer se llenó.
+var ErrSubscriberOverflow = errors.New("dictionary: se llenó el buffer de la suscripción")
+
+// EventKind es el tipo de un cambio del diccionario.
+type EventKind int
+
+const (
+	// EventPut indica que se agregó o actualizó una clave.
+	EventPut EventKind = iota + 1
+	// EventRemove indica que se eliminó una clave.
+	EventRemove
+	// EventClear indica que se eliminaron todas las claves.
+	EventClear
+)
+
+// String devuelve el nombre del tipo de evento.
+func (k EventKind) String() string {
+	switch k {
+	case EventPut:
+		return "Put"
+	case EventRemove:
+		return "Remove"
+	case EventClear:
+		return "Clear"
+	}
+	return "EventKind(?)"
+}
+
+// Event es un cambio del diccionario.
+type Event[K comparable, V any] struct {
+	// Kind es el tipo de cambio.
+	Kind EventKind
+	// Key es la clave modificada; el valor nulo en EventClear.
+	Key K
+	// OldValue es el valor anterior de la clave, en EventPut si Replaced es
+	// true y en EventRemove.
+	OldValue V
+	// NewValue es el nuevo valor de la clave, en EventPut.
+	NewValue V
+	// Replaced indica, en EventPut, si la clave ya existía.
+	Replaced bool
+}
+
+// Filter decide si un evento se envía a una suscripción. Se llama luego de
+// liberar el diccionario, en la goroutine de alguna de las modificaciones, por
+// lo que debe ser rápido y no debe modificar el diccionario.
+type Filter[K comparable, V any] func(Event[K, V]) bool
+
+// OverflowPolicy es lo que se hace con un evento cuando el buffer de la
+// suscripción está lleno.
+type OverflowPolicy int
+
+const (
+	// DropNewest descarta el evento nuevo.
+	DropNewest OverflowPolicy = iota
+	// DropOldest descarta el evento más antiguo del buffer para hacerle
+	// lugar al nuevo.
+	DropOldest
+	// Block espera a que haya lugar en el buffer. Mientras tanto, las
+	// modificaciones del diccionario se aplican pero no terminan hasta que se
+	// entreguen sus eventos; las lecturas no se ven afectadas.
+	Block
+	// Disconnect cancela la suscripción. Los eventos ya almacenados pueden
+	// seguir recibiéndose, y luego Err devuelve ErrSubscriberOverflow.
+	Disconnect
+)
+
+// Subscription es una suscripción a los cambios de un diccionario.
+type Subscription[K comparable, V any] struct {
+	events chan Event[K, V]
+	filter Filter[K, V]
+	policy OverflowPolicy
+	// mu evita que se cierre el canal mientras se envía un evento.
+	mu     sync.Mutex
+	closed bool
+	err    error
+	// done se cierra al cancelar la suscripción, para liberar a un envío
+	// bloqueado.
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// Subscribe crea una suscripción a los cambios del diccionario. Cada Put,
+// Remove o Clear que modifica el diccionario, incluidos los de una
+// transacción confirmada, publica un evento que se envía a las suscripciones
+// cuyo filtro lo acepta. Los eventos se reciben en el orden en que se
+// realizaron los cambios, y se envían luego de liberar el diccionario, por lo
+// que un suscriptor lento no bloquea las lecturas.
+//
+// Los Put y Remove de claves inválidas o inexistentes no publican eventos.
+// Clear publica un único evento, aunque el diccionario esté vacío.
+//
+// Uso:
+//
+//	sub := dict.Subscribe(nil, 100, dictionary.DropOldest)
+//	defer dict.Unsubscribe(sub)
+//	for event := range sub.Events() {
+//		fmt.Println(event.Kind, event.Key)
+//	}
+//
+// Parámetros:
+//   - `filter`: decide qué eventos se envían; si es nil, se envían todos.
+//   - `buffer`: la cantidad máxima de eventos almacenados sin recibir; si es
+//     menor a 1, se usa `DefaultBufferSize`.
+//   - `policy`: qué hacer con un evento cuando el buffer está lleno.
+//
+// Retorna:
+//   - la nueva suscripción.
+func (d *Dictionary[K, V]) Subscribe(filter Filter[K, V], buffer int, policy OverflowPolicy) *Subscription[K, V] {
+	if buffer < 1 {
+		buffer = DefaultBufferSize
+	}
+	s := &Subscription[K, V]{
+		events: make(chan Event[K, V], buffer),
+		filter: filter,
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	d.feed.add(s)
+	return s
+}
+
+// Unsubscribe cancela la suscripción y cierra su canal de eventos. Los
+// eventos ya almacenados pueden seguir recibiéndose.
+//
+// Uso:
+//
+//	dict.Unsubscribe(sub)
+//
+// Parámetros:
+//   - `s`: la suscripción a cancelar.
+//
+// Retorna:
+//   - `true` si se canceló la suscripción; `false` si ya estaba cancelada.
+func (d *Dictionary[K, V]) Unsubscribe(s *Subscription[K, V]) bool {
+	if !d.feed.remove(s) {
+		return false
+	}
+	s.close(nil)
+	return true
+}
+
+// Events devuelve el canal por el que se reciben los eventos. Se cierra al
+// cancelar la suscripción.
+func (s *Subscription[K, V]) Events() <-chan Event[K, V] {
+	return s.events
+}
+
+// Dropped devuelve la cantidad de eventos descartados porque el buffer estaba
+// lleno.
+func (s *Subscription[K, V]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Err devuelve ErrSubscriberOverflow si la suscripción se canceló porque se
+// llenó su buffer, o nil en caso contrario.
+func (s *Subscription[K, V]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// deliver envía el evento a la suscripción, si su filtro lo acepta, según su
+// política. Devuelve true si la suscripción debe cancelarse.
+func (s *Subscription[K, V]) deliver(event Event[K, V]) bool {
+	if s.filter != nil && !s.filter(event) {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	switch s.policy {
+	case Block:
+		select {
+		case s.events <- event:
+		case <-s.done:
+		}
+		return false
+	case DropOldest:
+		for {
+			select {
+			case s.events <- event:
+				return false
+			default:
+			}
+			select {
+			case <-s.events:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	}
+	select {
+	case s.events <- event:
+		return false
+	default:
+	}
+	if s.policy == Disconnect {
+		return true
+	}
+	s.dropped.Add(1)
+	return false
+}
+
+// close cierra el canal de eventos de la suscripción.
+func (s *Subscription[K, V]) close(err error) {
+	s.once.Do(func() {
+		// Se cierra done antes de tomar el lock para liberar a un envío
+		// bloqueado, que lo retiene.
+		close(s.done)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		s.err = err
+		close(s.events)
+	})
+}
+
+// delivery es un evento publicado y las suscripciones que existían al
+// publicarlo.
+type delivery[K comparable, V any] struct {
+	event Event[K, V]
+	subs  []*Subscription[K, V]
+}
+
+// feed es el conjunto de suscripciones de un diccionario.
+//
+// Los eventos se publican con el diccionario bloqueado, lo que fija su orden,
+// y se entregan con flush luego de liberarlo.
+type feed[K comparable, V any] struct {
+	mu sync.Mutex
+	// subs no se modifica en el lugar, porque los eventos pendientes
+	// comparten el arreglo.
+	subs []*Subscription[K, V]
+	// pending son los eventos publicados que todavía no se entregaron.
+	pending []delivery[K, V]
+	// delivering serializa las entregas, para que los eventos se reciban en
+	// el orden en que se publicaron.
+	delivering sync.Mutex
+}
+
+// add agrega la suscripción.
+func (f *feed[K, V]) add(s *Subscription[K, V]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subs = append(f.subs, s)
+}
+
+// remove quita la suscripción, y devuelve false si no estaba.
+func (f *feed[K, V]) remove(s *Subscription[K, V]) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	i := slices.Index(f.subs, s)
+	if i < 0 {
+		return false
+	}
+	f.subs = slices.Delete(slices.Clone(f.subs), i, i+1)
+	return true
+}
+
+// active indica si hay suscripciones, para evitar preparar eventos que nadie
+// va a recibir.
+func (f *feed[K, V]) active() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs) > 0
+}
+
+// publish agrega el evento a los pendientes de entrega. Debe llamarse con el
+// lock de escritura del diccionario tomado.
+func (f *feed[K, V]) publish(event Event[K, V]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.subs) > 0 {
+		f.pending = append(f.pending, delivery[K, V]{event: event, subs: f.subs})
+	}
+}
+
+// flush entrega los eventos pendientes, en orden. Debe llamarse luego de
+// liberar el lock del diccionario. Las suscripciones no se recorren con el
+// lock de feed tomado, para que una suscripción bloqueada no impida cancelar
+// otras.
+func (f *feed[K, V]) flush() {
+	f.mu.Lock()
+	idle := len(f.pending) == 0
+	f.mu.Unlock()
+	if idle {
+		return
+	}
+	f.delivering.Lock()
+	defer f.delivering.Unlock()
+	for {
+		f.mu.Lock()
+		pending := f.pending
+		f.pending = nil
+		f.mu.Unlock()
+		if len(pending) == 0 {
+			return
+		}
+		for _, d := range pending {
+			for _, s := range d.subs {
+				if s.deliver(d.event) && f.remove(s) {
+					s.close(ErrSubscriberOverflow)
+				}
+			}
+		}
+	}
+}
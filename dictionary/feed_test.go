@@ -0,0 +1,245 @@
+package dictionary
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drain devuelve los eventos almacenados en la suscripción, sin esperar.
+func drain[K comparable, V any](s *Subscription[K, V]) []Event[K, V] {
+	var events []Event[K, V]
+	for {
+		select {
+		case e, ok := <-s.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestSubscribeEventos(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+	sub := dict.Subscribe(nil, 0, DropNewest)
+	defer dict.Unsubscribe(sub)
+
+	dict.Put("uno", 10)
+	dict.Put("dos", 2)
+	dict.Remove("dos")
+	dict.Remove("tres")
+	dict.Clear()
+
+	assert.Equal(t, []Event[string, int]{
+		{Kind: EventPut, Key: "uno", OldValue: 1, NewValue: 10, Replaced: true},
+		{Kind: EventPut, Key: "dos", NewValue: 2},
+		{Kind: EventRemove, Key: "dos", OldValue: 2},
+		{Kind: EventClear},
+	}, drain(sub))
+}
+
+func TestSubscribeFiltro(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	sub := dict.Subscribe(func(e Event[string, int]) bool {
+		return e.Kind == EventRemove
+	}, 0, DropNewest)
+	defer dict.Unsubscribe(sub)
+
+	dict.Put("uno", 1)
+	dict.Remove("uno")
+
+	assert.Equal(t, []Event[string, int]{{Kind: EventRemove, Key: "uno", OldValue: 1}}, drain(sub))
+}
+
+func TestUnsubscribe(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	sub := dict.Subscribe(nil, 0, DropNewest)
+	dict.Put("uno", 1)
+
+	assert.True(t, dict.Unsubscribe(sub))
+	assert.False(t, dict.Unsubscribe(sub))
+	dict.Put("dos", 2)
+
+	// Los eventos almacenados se reciben, y luego el canal está cerrado.
+	assert.Len(t, drain(sub), 1)
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.NoError(t, sub.Err())
+}
+
+func TestSubscribeDropNewest(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	sub := dict.Subscribe(nil, 2, DropNewest)
+	defer dict.Unsubscribe(sub)
+	for i := range 5 {
+		dict.Put(i, i)
+	}
+
+	events := drain(sub)
+	require.Len(t, events, 2)
+	assert.Equal(t, []int{0, 1}, []int{events[0].Key, events[1].Key})
+	assert.Equal(t, uint64(3), sub.Dropped())
+}
+
+func TestSubscribeDropOldest(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	sub := dict.Subscribe(nil, 2, DropOldest)
+	defer dict.Unsubscribe(sub)
+	for i := range 5 {
+		dict.Put(i, i)
+	}
+
+	events := drain(sub)
+	require.Len(t, events, 2)
+	assert.Equal(t, []int{3, 4}, []int{events[0].Key, events[1].Key})
+	assert.Equal(t, uint64(3), sub.Dropped())
+}
+
+func TestSubscribeDisconnect(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	sub := dict.Subscribe(nil, 2, Disconnect)
+	for i := range 5 {
+		dict.Put(i, i)
+	}
+
+	assert.Len(t, drain(sub), 2)
+	assert.ErrorIs(t, sub.Err(), ErrSubscriberOverflow)
+	assert.False(t, dict.Unsubscribe(sub))
+}
+
+func TestSubscribeBlock(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	sub := dict.Subscribe(nil, 1, Block)
+	defer dict.Unsubscribe(sub)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 10 {
+			dict.Put(i, i)
+		}
+	}()
+
+	var keys []int
+	for range 10 {
+		keys = append(keys, (<-sub.Events()).Key)
+	}
+	<-done
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+	assert.Equal(t, uint64(0), sub.Dropped())
+}
+
+func TestUnsubscribeLiberaEscrituraBloqueada(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	sub := dict.Subscribe(nil, 1, Block)
+	dict.Put(1, 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dict.Put(2, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	dict.Unsubscribe(sub)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("la escritura sigue bloqueada")
+	}
+	assert.Equal(t, 2, dict.Size())
+}
+
+func TestSubscribeBlockNoBloqueaElDiccionario(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	stuck := dict.Subscribe(nil, 1, Block)
+	other := dict.Subscribe(nil, 0, DropNewest)
+	defer dict.Unsubscribe(other)
+	dict.Put(1, 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dict.Put(2, 2)
+	}()
+
+	// La escritura espera a que stuck reciba su evento, pero con el
+	// diccionario liberado: se puede leer, cancelar otras suscripciones y
+	// cancelar la bloqueada.
+	require.Eventually(t, func() bool { return dict.Contains(2) }, time.Second, time.Millisecond)
+	assert.Equal(t, 2, dict.Size())
+	select {
+	case <-done:
+		t.Fatal("la escritura no esperó a la suscripción bloqueada")
+	default:
+	}
+	unsubscribed := make(chan bool)
+	go func() { unsubscribed <- dict.Unsubscribe(stuck) }()
+	select {
+	case ok := <-unsubscribed:
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("Unsubscribe quedó bloqueado")
+	}
+	<-done
+
+	assert.Equal(t, []Event[int, int]{
+		{Kind: EventPut, Key: 1, NewValue: 1},
+		{Kind: EventPut, Key: 2, NewValue: 2},
+	}, drain(other))
+}
+
+func TestSubscribeTransaccion(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.Put("dos", 2)
+	sub := dict.Subscribe(nil, 0, DropNewest)
+	defer dict.Unsubscribe(sub)
+
+	tx := dict.Begin()
+	tx.Put("uno", 10)
+	tx.Remove("dos")
+	tx.Put("tres", 3)
+	tx.Remove("tres")
+	assert.Empty(t, drain(sub))
+	require.NoError(t, tx.Commit())
+
+	assert.ElementsMatch(t, []Event[string, int]{
+		{Kind: EventPut, Key: "uno", OldValue: 1, NewValue: 10, Replaced: true},
+		{Kind: EventRemove, Key: "dos", OldValue: 2},
+	}, drain(sub))
+}
+
+func TestSubscribeEscriturasConcurrentes(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	sub := dict.Subscribe(nil, 1000, DropNewest)
+	defer dict.Unsubscribe(sub)
+
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				dict.Put(w*100+i, i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, drain(sub), 400)
+	assert.Equal(t, 400, dict.Size())
+}
+
+func TestEventKindString(t *testing.T) {
+	assert.Equal(t, "Put", EventPut.String())
+	assert.Equal(t, "Remove", EventRemove.String())
+	assert.Equal(t, "Clear", EventClear.String())
+}
@@ -0,0 +1,681 @@
+package dictionary
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+var (
+	// ErrVersionCollected indica que la versión pedida ya no puede leerse
+	// porque su historia se descartó, al no haber snapshots que la usen y
+	// estar fuera de la ventana de retención.
+	ErrVersionCollected = errors.New("dictionary: la versión ya fue descartada")
+	// ErrFutureVersion indica que la versión pedida todavía no existe.
+	ErrFutureVersion = errors.New("dictionary: la versión todavía no existe")
+	// ErrSnapshotReleased indica que el snapshot ya se liberó.
+	ErrSnapshotReleased = errors.New("dictionary: el snapshot ya fue liberado")
+)
+
+// Version identifica el estado de un MVCCDictionary, o de un Dictionary que
+// registra versiones, luego de una escritura. La versión 0 es el diccionario
+// recién creado, o el contenido de un Dictionary al activar sus versiones.
+type Version uint64
+
+// revision es el valor de una clave a partir de una versión.
+type revision[V any] struct {
+	// version es la versión en la que se escribió el valor.
+	version Version
+	// value es el valor escrito, si no se eliminó la clave.
+	value V
+	// deleted indica si en esa versión se eliminó la clave.
+	deleted bool
+}
+
+// MVCCDictionary es un diccionario con control de concurrencia multiversión
+// (MVCC): cada escritura crea una nueva versión, y las versiones anteriores
+// pueden seguir leyéndose mientras haya un snapshot que las use.
+//
+// Cada clave guarda la lista de sus valores, ordenada por versión, en una
+// hashtable.HashTable. Un snapshot fija una versión: sus lecturas ven el
+// diccionario tal como estaba en esa versión, aunque luego se sigan
+// realizando escrituras.
+//
+// Los valores que ya no son visibles desde la versión actual, desde ningún
+// snapshot ni desde la ventana de retención (ver SetRetention) se descartan al
+// escribir o al liberar un snapshot. Cada descarte revisa solo las claves
+// escritas desde el anterior. GetAt puede leer las versiones posteriores al
+// snapshot más antiguo que siga abierto y las de la ventana de retención, que
+// por defecto está vacía.
+//
+// Un Dictionary puede registrar sus versiones en un MVCCDictionary (ver
+// Dictionary.EnableVersioning), conservando sus transacciones, suscripciones
+// y DefaultDictionary.
+//
+// Es seguro para uso concurrente.
+type MVCCDictionary[K comparable, V any] struct {
+	mu sync.RWMutex
+	// history es la lista de valores de cada clave, de la versión más antigua
+	// a la más reciente.
+	history *hashtable.HashTable[K, []revision[V]]
+	// version es la versión actual.
+	version Version
+	// size es la cantidad de claves en la versión actual.
+	size int
+	// retention es la cantidad de versiones anteriores a la actual que se
+	// conservan aunque no haya snapshots que las usen.
+	retention Version
+	// pinned asocia cada versión con snapshots abiertos con su posición en
+	// pins.
+	pinned *hashtable.HashTable[Version, *pin]
+	// pins es un heap de mínimos de las versiones con snapshots abiertos.
+	pins pinHeap
+	// retired son las claves con valores que dejan de ser visibles cuando la
+	// versión más antigua que puede leerse alcanza la indicada, en orden de
+	// versión.
+	retired []retiredKey[K]
+}
+
+// NewMVCCDictionary crea un nuevo diccionario multiversión vacío, en la
+// versión 0.
+//
+// Uso:
+//
+//	dict := dictionary.NewMVCCDictionary[string, int]()
+//	v, _ := dict.Put("uno", 1)
+//	snap := dict.Snapshot()
+//	defer snap.Release()
+//	dict.Put("uno", 10)
+//	snap.Get("uno")       // Devuelve 1.
+//	dict.GetAt("uno", v)  // Devuelve 1.
+func NewMVCCDictionary[K comparable, V any]() *MVCCDictionary[K, V] {
+	return &MVCCDictionary[K, V]{
+		history: hashtable.NewHashTable[K, []revision[V]](0, 0),
+		pinned:  hashtable.NewHashTable[Version, *pin](0, 0),
+	}
+}
+
+// SetRetention establece la cantidad de versiones anteriores a la actual que
+// pueden leerse con GetAt aunque no haya snapshots que las usen. Al reducirla
+// se descarta la historia que queda fuera de la ventana.
+//
+// Uso:
+//
+//	dict.SetRetention(100) // GetAt puede leer las últimas 100 versiones.
+//
+// Parámetros:
+//   - `n`: la cantidad de versiones a conservar; 0 conserva solo las que usan
+//     los snapshots abiertos.
+func (d *MVCCDictionary[K, V]) SetRetention(n Version) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.retention = n
+	d.collect()
+}
+
+// Put agrega o actualiza un par clave-valor, creando una nueva versión.
+//
+// Uso:
+//
+//	v, err := dict.Put("uno", 1)
+//
+// Parámetros:
+//   - `key`: la clave.
+//   - `value`: el valor a asociar a la clave.
+//
+// Retorna:
+//   - la versión creada y `nil`; la versión actual y un error que envuelve
+//     `ErrInvalidKey` o `ErrCapacityExceeded` si no se agregó el par.
+func (d *MVCCDictionary[K, V]) Put(key K, value V) (Version, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	revs, _ := d.history.Get(key)
+	existed := len(revs) > 0 && !revs[len(revs)-1].deleted
+	d.version++
+	revs = d.prune(append(revs, revision[V]{version: d.version, value: value}), d.horizon())
+	if err := d.history.TryPut(key, revs); err != nil {
+		d.version--
+		return d.version, err
+	}
+	if len(revs) > 1 {
+		d.retire(key)
+	}
+	if !existed {
+		d.size++
+	}
+	d.collect()
+	return d.version, nil
+}
+
+// Remove elimina la clave, creando una nueva versión.
+//
+// Uso:
+//
+//	v, ok := dict.Remove("uno")
+//
+// Parámetros:
+//   - `key`: la clave a eliminar.
+//
+// Retorna:
+//   - la versión creada y `true` si se eliminó la clave; la versión actual y
+//     `false` si la clave no existe, en cuyo caso no se crea una versión.
+func (d *MVCCDictionary[K, V]) Remove(key K) (Version, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	revs, _ := d.history.Get(key)
+	if len(revs) == 0 || revs[len(revs)-1].deleted {
+		return d.version, false
+	}
+	d.version++
+	d.size--
+	d.store(key, append(revs, revision[V]{version: d.version, deleted: true}), d.horizon())
+	d.collect()
+	return d.version, true
+}
+
+// Clear elimina todas las claves, creando una única nueva versión.
+//
+// Uso:
+//
+//	v := dict.Clear()
+//
+// Retorna:
+//   - la versión creada; la versión actual si el diccionario ya estaba vacío.
+func (d *MVCCDictionary[K, V]) Clear() Version {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size == 0 {
+		return d.version
+	}
+	d.version++
+	d.size = 0
+	horizon := d.horizon()
+	for _, key := range d.history.Keys() {
+		revs, _ := d.history.Get(key)
+		if !revs[len(revs)-1].deleted {
+			revs = append(revs, revision[V]{version: d.version, deleted: true})
+		}
+		d.store(key, revs, horizon)
+	}
+	d.collect()
+	return d.version
+}
+
+// Get devuelve el valor asociado a la clave en la versión actual.
+//
+// Uso:
+//
+//	value := dict.Get("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave; el valor nulo si la clave no existe.
+func (d *MVCCDictionary[K, V]) Get(key K) V {
+	value, _ := d.TryGet(key)
+	return value
+}
+
+// TryGet devuelve el valor asociado a la clave en la versión actual, o un
+// error si la clave no existe.
+//
+// Uso:
+//
+//	value, err := dict.TryGet("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave y `nil`; el valor nulo y un error que
+//     envuelve `ErrNotFound` si la clave no existe.
+func (d *MVCCDictionary[K, V]) TryGet(key K) (V, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.lookup(key, d.version)
+}
+
+// GetAt devuelve el valor que tenía la clave en la versión dada.
+//
+// Uso:
+//
+//	value, err := dict.GetAt("uno", v)
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//   - `version`: la versión a leer.
+//
+// Retorna:
+//   - el valor asociado a la clave en esa versión y `nil`; el valor nulo y un
+//     error que envuelve `ErrNotFound` si la clave no existía en esa versión,
+//     `ErrVersionCollected` si la versión ya se descartó o `ErrFutureVersion`
+//     si todavía no existe.
+func (d *MVCCDictionary[K, V]) GetAt(key K, version Version) (V, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var zero V
+	if version > d.version {
+		return zero, fmt.Errorf("%w: %d", ErrFutureVersion, version)
+	}
+	if version < d.horizon() {
+		return zero, fmt.Errorf("%w: %d", ErrVersionCollected, version)
+	}
+	return d.lookup(key, version)
+}
+
+// Contains verifica si la clave existe en la versión actual.
+//
+// Uso:
+//
+//	exists := dict.Contains("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - `true` si la clave existe; `false` en caso contrario.
+func (d *MVCCDictionary[K, V]) Contains(key K) bool {
+	_, err := d.TryGet(key)
+	return err == nil
+}
+
+// Keys devuelve las claves de la versión actual.
+//
+// Uso:
+//
+//	keys := dict.Keys()
+//
+// Retorna:
+//   - las claves de la versión actual como un slice.
+func (d *MVCCDictionary[K, V]) Keys() []K {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	keys, _ := d.entries(d.version)
+	return keys
+}
+
+// Values devuelve los valores de la versión actual.
+//
+// Uso:
+//
+//	values := dict.Values()
+//
+// Retorna:
+//   - los valores de la versión actual como un slice.
+func (d *MVCCDictionary[K, V]) Values() []V {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, values := d.entries(d.version)
+	return values
+}
+
+// Size devuelve la cantidad de claves de la versión actual.
+//
+// Uso:
+//
+//	size := dict.Size()
+//
+// Retorna:
+//   - la cantidad de claves de la versión actual.
+func (d *MVCCDictionary[K, V]) Size() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.size
+}
+
+// IsEmpty evalúa si el diccionario está vacío en la versión actual.
+//
+// Uso:
+//
+//	empty := dict.IsEmpty()
+//
+// Retorna:
+//   - `true` si el diccionario está vacío; `false` en caso contrario.
+func (d *MVCCDictionary[K, V]) IsEmpty() bool {
+	return d.Size() == 0
+}
+
+// Version devuelve la versión actual.
+//
+// Uso:
+//
+//	v := dict.Version()
+//
+// Retorna:
+//   - la versión actual.
+func (d *MVCCDictionary[K, V]) Version() Version {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.version
+}
+
+// Oldest devuelve la versión más antigua que todavía puede leerse con GetAt:
+// la del snapshot abierto más antiguo o la primera de la ventana de
+// retención, la que sea anterior.
+//
+// Uso:
+//
+//	v := dict.Oldest()
+//
+// Retorna:
+//   - la versión más antigua que puede leerse.
+func (d *MVCCDictionary[K, V]) Oldest() Version {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.horizon()
+}
+
+// Snapshot devuelve una vista de solo lectura fijada en la versión actual.
+// Mientras el snapshot esté abierto, sus lecturas no cambian aunque se
+// realicen escrituras, y la historia necesaria para responderlas se conserva.
+//
+// El snapshot debe liberarse con Release cuando ya no se use. Si se pierde
+// la referencia sin liberarlo, se libera cuando lo recolecta el recolector de
+// basura.
+//
+// Uso:
+//
+//	snap := dict.Snapshot()
+//	defer snap.Release()
+//
+// Retorna:
+//   - el snapshot de la versión actual.
+func (d *MVCCDictionary[K, V]) Snapshot() *Snapshot[K, V] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p, ok := d.pinned.Get(d.version)
+	if !ok {
+		p = &pin{version: d.version}
+		d.pinned.Put(d.version, p)
+		heap.Push(&d.pins, p)
+	}
+	p.count++
+	s := &Snapshot[K, V]{dict: d, version: d.version}
+	// La función de limpieza no debe referenciar al snapshot, ya que eso
+	// impediría liberarlo.
+	s.cleanup = runtime.AddCleanup(s, d.unpin, d.version)
+	return s
+}
+
+// String devuelve una representación en cadena de la versión actual.
+//
+// Uso:
+//
+//	fmt.Println(dict)
+//
+// Retorna:
+//   - una representación en cadena de la versión actual.
+func (d *MVCCDictionary[K, V]) String() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return fmt.Sprintf("MVCCDictionary@%d: %s", d.version, d.format(d.version))
+}
+
+// Snapshot es una vista de solo lectura de un MVCCDictionary fijada en una
+// versión. Es segura para uso concurrente.
+type Snapshot[K comparable, V any] struct {
+	dict    *MVCCDictionary[K, V]
+	version Version
+	once    sync.Once
+	cleanup runtime.Cleanup
+	// released indica si el snapshot se liberó; se lee con el lock del
+	// diccionario.
+	released bool
+}
+
+// Version devuelve la versión en la que está fijado el snapshot.
+func (s *Snapshot[K, V]) Version() Version {
+	return s.version
+}
+
+// Get devuelve el valor asociado a la clave en la versión del snapshot.
+//
+// - Si la clave no existe o el snapshot se liberó, devuelve el valor nulo.
+func (s *Snapshot[K, V]) Get(key K) V {
+	value, _ := s.TryGet(key)
+	return value
+}
+
+// TryGet devuelve el valor asociado a la clave en la versión del snapshot.
+//
+// - Si la clave no existe, devuelve un error que envuelve ErrNotFound.
+// - Si el snapshot se liberó, devuelve ErrSnapshotReleased.
+func (s *Snapshot[K, V]) TryGet(key K) (V, error) {
+	s.dict.mu.RLock()
+	defer s.dict.mu.RUnlock()
+	if s.released {
+		var zero V
+		return zero, ErrSnapshotReleased
+	}
+	return s.dict.lookup(key, s.version)
+}
+
+// Contains verifica si la clave existe en la versión del snapshot.
+func (s *Snapshot[K, V]) Contains(key K) bool {
+	_, err := s.TryGet(key)
+	return err == nil
+}
+
+// Keys devuelve las claves de la versión del snapshot.
+//
+// - Si el snapshot se liberó, devuelve nil.
+func (s *Snapshot[K, V]) Keys() []K {
+	s.dict.mu.RLock()
+	defer s.dict.mu.RUnlock()
+	if s.released {
+		return nil
+	}
+	keys, _ := s.dict.entries(s.version)
+	return keys
+}
+
+// Values devuelve los valores de la versión del snapshot.
+//
+// - Si el snapshot se liberó, devuelve nil.
+func (s *Snapshot[K, V]) Values() []V {
+	s.dict.mu.RLock()
+	defer s.dict.mu.RUnlock()
+	if s.released {
+		return nil
+	}
+	_, values := s.dict.entries(s.version)
+	return values
+}
+
+// Size devuelve la cantidad de claves de la versión del snapshot.
+//
+// - Si el snapshot se liberó, devuelve 0.
+func (s *Snapshot[K, V]) Size() int {
+	return len(s.Keys())
+}
+
+// Release libera el snapshot. Luego, la historia que solo él usaba puede
+// descartarse. Llamarlo más de una vez no tiene efecto.
+func (s *Snapshot[K, V]) Release() {
+	s.once.Do(func() {
+		s.cleanup.Stop()
+		s.dict.mu.Lock()
+		s.released = true
+		s.dict.mu.Unlock()
+		s.dict.unpin(s.version)
+	})
+}
+
+// String devuelve una representación en cadena de la versión del snapshot.
+func (s *Snapshot[K, V]) String() string {
+	s.dict.mu.RLock()
+	defer s.dict.mu.RUnlock()
+	if s.released {
+		return fmt.Sprintf("Snapshot@%d: liberado", s.version)
+	}
+	return fmt.Sprintf("Snapshot@%d: %s", s.version, s.dict.format(s.version))
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// horizon devuelve la versión más antigua que puede leerse. Los valores que
+// solo son visibles en versiones anteriores pueden descartarse.
+func (d *MVCCDictionary[K, V]) horizon() Version {
+	horizon := d.version - min(d.retention, d.version)
+	if len(d.pins) > 0 {
+		horizon = min(horizon, d.pins[0].version)
+	}
+	return horizon
+}
+
+// prune descarta los valores de la lista que no son visibles en ninguna
+// versión posterior o igual a horizon.
+func (d *MVCCDictionary[K, V]) prune(revs []revision[V], horizon Version) []revision[V] {
+	first := 0
+	for i := len(revs) - 1; i >= 0; i-- {
+		if revs[i].version <= horizon {
+			first = i
+			break
+		}
+	}
+	// Se copian los valores visibles para que el arreglo anterior, con los
+	// valores descartados, pueda liberarse.
+	return append([]revision[V](nil), revs[first:]...)
+}
+
+// store guarda la lista de valores de la clave, luego de descartar los que ya
+// no son visibles. Si solo queda una eliminación, descarta la clave.
+func (d *MVCCDictionary[K, V]) store(key K, revs []revision[V], horizon Version) {
+	revs = d.prune(revs, horizon)
+	if len(revs) == 1 && revs[0].deleted && revs[0].version <= horizon {
+		d.history.Remove(key)
+		return
+	}
+	d.history.Put(key, revs)
+	if len(revs) > 1 || revs[0].deleted {
+		d.retire(key)
+	}
+}
+
+// retire registra que la clave tiene valores que dejan de ser visibles cuando
+// la versión más antigua que puede leerse alcance la actual.
+func (d *MVCCDictionary[K, V]) retire(key K) {
+	d.retired = append(d.retired, retiredKey[K]{version: d.version, key: key})
+}
+
+// collect descarta los valores que ya no son visibles de las claves retiradas
+// en versiones que ya no pueden leerse.
+func (d *MVCCDictionary[K, V]) collect() {
+	horizon := d.horizon()
+	n := 0
+	for n < len(d.retired) && d.retired[n].version <= horizon {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	// Se quitan antes de guardar, ya que store puede volver a retirar una
+	// clave que siga teniendo valores de versiones posteriores.
+	collected := slices.Clone(d.retired[:n])
+	clear(d.retired[:n])
+	d.retired = d.retired[n:]
+	for _, r := range collected {
+		if revs, ok := d.history.Get(r.key); ok {
+			d.store(r.key, revs, horizon)
+		}
+	}
+}
+
+// unpin libera un snapshot de la versión y, si era el último de la versión,
+// descarta los valores que ya no son visibles.
+func (d *MVCCDictionary[K, V]) unpin(version Version) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p, _ := d.pinned.Get(version)
+	if p.count--; p.count > 0 {
+		return
+	}
+	d.pinned.Remove(version)
+	heap.Remove(&d.pins, p.index)
+	d.collect()
+}
+
+// lookup devuelve el valor de la clave en la versión dada.
+func (d *MVCCDictionary[K, V]) lookup(key K, version Version) (V, error) {
+	revs, _ := d.history.Get(key)
+	for i := len(revs) - 1; i >= 0; i-- {
+		if revs[i].version <= version {
+			if revs[i].deleted {
+				break
+			}
+			return revs[i].value, nil
+		}
+	}
+	var zero V
+	return zero, fmt.Errorf("%w: %v", ErrNotFound, key)
+}
+
+// entries devuelve las claves y los valores de la versión dada.
+func (d *MVCCDictionary[K, V]) entries(version Version) ([]K, []V) {
+	var keys []K
+	var values []V
+	for it := d.history.Iterator(); it.Next(); {
+		if value, err := d.lookup(it.Key(), version); err == nil {
+			keys = append(keys, it.Key())
+			values = append(values, value)
+		}
+	}
+	return keys, values
+}
+
+// format devuelve la representación en cadena de la versión dada.
+func (d *MVCCDictionary[K, V]) format(version Version) string {
+	keys, values := d.entries(version)
+	parts := make([]string, len(keys))
+	for i := range keys {
+		parts[i] = fmt.Sprintf("%v: %v", keys[i], values[i])
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// retiredKey es una clave con valores que dejan de ser visibles cuando la
+// versión más antigua que puede leerse alcanza version.
+type retiredKey[K any] struct {
+	version Version
+	key     K
+}
+
+// pin es una versión con snapshots abiertos junto con su posición en el heap.
+type pin struct {
+	version Version
+	// count es la cantidad de snapshots abiertos en la versión.
+	count int
+	index int
+}
+
+// pinHeap implementa heap.Interface como un heap de mínimos por versión.
+type pinHeap []*pin
+
+func (h pinHeap) Len() int           { return len(h) }
+func (h pinHeap) Less(i, j int) bool { return h[i].version < h[j].version }
+
+func (h pinHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *pinHeap) Push(x any) {
+	p := x.(*pin)
+	p.index = len(*h)
+	*h = append(*h, p)
+}
+
+func (h *pinHeap) Pop() any {
+	old := *h
+	p := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return p
+}
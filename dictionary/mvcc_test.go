@@ -0,0 +1,248 @@
+package dictionary
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// revisions devuelve la cantidad de valores guardados para la clave.
+func revisions[K comparable, V any](d *MVCCDictionary[K, V], key K) int {
+	revs, _ := d.history.Get(key)
+	return len(revs)
+}
+
+func TestNewMVCCDictionary(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+
+	assert.True(t, dict.IsEmpty())
+	assert.Equal(t, Version(0), dict.Version())
+	assert.Equal(t, "MVCCDictionary@0: {}", dict.String())
+}
+
+func TestMVCCCadaEscrituraCreaUnaVersion(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+
+	v1, err := dict.Put("uno", 1)
+	require.NoError(t, err)
+	v2, _ := dict.Put("dos", 2)
+	v3, _ := dict.Put("uno", 10)
+	v4, ok := dict.Remove("dos")
+	assert.True(t, ok)
+	v5, ok := dict.Remove("dos")
+	assert.False(t, ok)
+
+	assert.Equal(t, []Version{1, 2, 3, 4, 4}, []Version{v1, v2, v3, v4, v5})
+	assert.Equal(t, 1, dict.Size())
+	assert.Equal(t, 10, dict.Get("uno"))
+	assert.False(t, dict.Contains("dos"))
+	assert.Equal(t, "MVCCDictionary@4: {uno: 10}", dict.String())
+}
+
+func TestMVCCClaveInvalida(t *testing.T) {
+	dict := NewMVCCDictionary[float64, string]()
+
+	v, err := dict.Put(math.NaN(), "nan")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.Equal(t, Version(0), v)
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestMVCCSnapshot(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.Put("dos", 2)
+
+	snap := dict.Snapshot()
+	defer snap.Release()
+	dict.Put("uno", 10)
+	dict.Remove("dos")
+	dict.Put("tres", 3)
+
+	assert.Equal(t, Version(2), snap.Version())
+	assert.Equal(t, 1, snap.Get("uno"))
+	assert.Equal(t, 2, snap.Get("dos"))
+	assert.False(t, snap.Contains("tres"))
+	assert.ElementsMatch(t, []string{"uno", "dos"}, snap.Keys())
+	assert.ElementsMatch(t, []int{1, 2}, snap.Values())
+	assert.Equal(t, 2, snap.Size())
+
+	assert.ElementsMatch(t, []string{"uno", "tres"}, dict.Keys())
+	assert.ElementsMatch(t, []int{10, 3}, dict.Values())
+}
+
+func TestMVCCGetAt(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+	snap := dict.Snapshot()
+	defer snap.Release()
+
+	v1, _ := dict.Put("uno", 1)
+	v2, _ := dict.Put("uno", 2)
+	v3, _ := dict.Remove("uno")
+	v4 := dict.Clear()
+
+	value, err := dict.GetAt("uno", v1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, value)
+	value, _ = dict.GetAt("uno", v2)
+	assert.Equal(t, 2, value)
+	_, err = dict.GetAt("uno", v3)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = dict.GetAt("uno", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, v3, v4)
+	_, err = dict.GetAt("uno", v4+1)
+	assert.ErrorIs(t, err, ErrFutureVersion)
+}
+
+func TestMVCCDescartaVersionesSinSnapshots(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+	for i := range 10 {
+		dict.Put("uno", i)
+	}
+
+	assert.Equal(t, 1, revisions(dict, "uno"))
+	assert.Equal(t, dict.Version(), dict.Oldest())
+	_, err := dict.GetAt("uno", 5)
+	assert.ErrorIs(t, err, ErrVersionCollected)
+
+	dict.Remove("uno")
+	assert.Equal(t, 0, revisions(dict, "uno"))
+	assert.Equal(t, uint(0), dict.history.Size())
+}
+
+func TestMVCCReleaseDescartaVersiones(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.Put("dos", 2)
+	old := dict.Snapshot()
+	dict.Put("uno", 10)
+	dict.Remove("dos")
+	recent := dict.Snapshot()
+	dict.Put("uno", 100)
+
+	assert.Equal(t, 3, revisions(dict, "uno"))
+	assert.Equal(t, 2, revisions(dict, "dos"))
+	assert.Equal(t, old.Version(), dict.Oldest())
+
+	old.Release()
+	old.Release()
+	assert.Equal(t, recent.Version(), dict.Oldest())
+	assert.Equal(t, 2, revisions(dict, "uno"))
+	assert.Equal(t, 0, revisions(dict, "dos"))
+	assert.Equal(t, 10, recent.Get("uno"))
+	_, err := old.TryGet("uno")
+	assert.ErrorIs(t, err, ErrSnapshotReleased)
+	assert.Nil(t, old.Keys())
+
+	recent.Release()
+	assert.Equal(t, 1, revisions(dict, "uno"))
+	assert.Equal(t, 100, dict.Get("uno"))
+}
+
+func TestMVCCSnapshotsEnLaMismaVersion(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+	dict.Put("uno", 1)
+	a := dict.Snapshot()
+	b := dict.Snapshot()
+	dict.Put("uno", 2)
+
+	a.Release()
+	assert.Equal(t, 1, b.Get("uno"))
+	b.Release()
+	assert.Equal(t, 1, revisions(dict, "uno"))
+}
+
+func TestMVCCSetRetention(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+	dict.SetRetention(3)
+	for i := 1; i <= 10; i++ {
+		dict.Put("uno", i)
+		dict.Put("dos", -i)
+	}
+
+	// Sin snapshots abiertos, GetAt lee las últimas 3 versiones.
+	assert.Equal(t, dict.Version()-3, dict.Oldest())
+	for v := dict.Oldest(); v <= dict.Version(); v++ {
+		value, err := dict.GetAt("uno", v)
+		require.NoError(t, err, "versión %d", v)
+		assert.Equal(t, int(v+1)/2, value)
+	}
+	_, err := dict.GetAt("uno", dict.Oldest()-1)
+	assert.ErrorIs(t, err, ErrVersionCollected)
+	assert.Equal(t, 2, revisions(dict, "uno"))
+
+	dict.SetRetention(0)
+	assert.Equal(t, dict.Version(), dict.Oldest())
+	assert.Equal(t, 1, revisions(dict, "uno"))
+	assert.Equal(t, 1, revisions(dict, "dos"))
+	assert.Empty(t, dict.retired)
+}
+
+func TestMVCCReleaseFueraDeOrden(t *testing.T) {
+	dict := NewMVCCDictionary[string, int]()
+	var snaps []*Snapshot[string, int]
+	for i := range 5 {
+		dict.Put("uno", i)
+		snaps = append(snaps, dict.Snapshot())
+	}
+	dict.Put("dos", 2)
+
+	// Oldest es siempre la versión del snapshot abierto más antiguo.
+	for _, i := range []int{2, 0, 4, 1} {
+		snaps[i].Release()
+	}
+	assert.Equal(t, snaps[3].Version(), dict.Oldest())
+	assert.Equal(t, 3, snaps[3].Get("uno"))
+	assert.Equal(t, 2, revisions(dict, "uno"))
+
+	snaps[3].Release()
+	assert.Equal(t, dict.Version(), dict.Oldest())
+	assert.Equal(t, 1, revisions(dict, "uno"))
+	assert.Empty(t, dict.pins)
+	assert.Equal(t, uint(0), dict.pinned.Size())
+	assert.Empty(t, dict.retired)
+}
+
+func TestMVCCConcurrente(t *testing.T) {
+	dict := NewMVCCDictionary[int, int]()
+	for i := range 100 {
+		dict.Put(i, 0)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for round := 1; round <= 50; round++ {
+			for i := range 100 {
+				dict.Put(i, round)
+			}
+		}
+	}()
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				snap := dict.Snapshot()
+				// Las escrituras de una ronda no se mezclan con las de la
+				// siguiente: los valores de un snapshot difieren a lo sumo en 1.
+				values := snap.Values()
+				lo, hi := values[0], values[0]
+				for _, v := range values {
+					lo, hi = min(lo, v), max(hi, v)
+				}
+				assert.LessOrEqual(t, hi-lo, 1, fmt.Sprint(snap))
+				assert.Equal(t, values, snap.Values())
+				snap.Release()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, dict.Size())
+}
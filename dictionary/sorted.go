@@ -0,0 +1,737 @@
+package dictionary
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"sync"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// avlNode es un nodo del árbol AVL de un SortedDictionary.
+type avlNode[K cmp.Ordered, V any] struct {
+	key         K
+	value       V
+	left, right *avlNode[K, V]
+	// height es la altura del subárbol; una hoja tiene altura 1.
+	height int
+	// size es la cantidad de nodos del subárbol, que permite calcular el
+	// rango de una clave y seleccionar la i-ésima en tiempo logarítmico.
+	size int
+}
+
+// SortedDictionary es un diccionario cuyas claves se mantienen ordenadas, en
+// un árbol AVL. Las operaciones básicas tienen costo O(log n), y además de
+// las de Dictionary permite consultar por orden: mínimo, máximo, piso, techo,
+// rangos de claves y rango de una clave.
+//
+// Es seguro para uso concurrente.
+type SortedDictionary[K cmp.Ordered, V any] struct {
+	mu   sync.RWMutex
+	root *avlNode[K, V]
+	// modCount cuenta las modificaciones estructurales, para detectarlas
+	// durante una iteración.
+	modCount uint
+}
+
+// NewSortedDictionary crea un nuevo diccionario ordenado vacío.
+//
+// Uso:
+//
+//	dict := dictionary.NewSortedDictionary[string, int]()
+func NewSortedDictionary[K cmp.Ordered, V any]() *SortedDictionary[K, V] {
+	return &SortedDictionary[K, V]{}
+}
+
+// Put agrega un par clave-valor al diccionario. Si la clave ya existe,
+// reemplaza el valor asociado.
+//
+// Uso:
+//
+//	dict.Put("uno", 1)
+//
+// Parámetros:
+//   - `key`: la clave.
+//   - `value`: el valor a asociar a la clave.
+//
+// Retorna:
+//   - `true` si se agregó o actualizó el par; `false` si la clave no es válida.
+func (d *SortedDictionary[K, V]) Put(key K, value V) bool {
+	return d.TryPut(key, value) == nil
+}
+
+// TryPut agrega un par clave-valor al diccionario. Si la clave ya existe,
+// reemplaza el valor asociado.
+//
+// Uso:
+//
+//	err := dict.TryPut("uno", 1)
+//
+// Parámetros:
+//   - `key`: la clave.
+//   - `value`: el valor a asociar a la clave.
+//
+// Retorna:
+//   - `nil` si se agregó o actualizó el par; un error que envuelve
+//     `ErrInvalidKey` si la clave no es igual a sí misma (NaN).
+func (d *SortedDictionary[K, V]) TryPut(key K, value V) error {
+	if key != key {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var added bool
+	d.root, added = insert(d.root, key, value)
+	if added {
+		d.modCount++
+	}
+	return nil
+}
+
+// Get devuelve el valor asociado a la clave.
+//
+// Uso:
+//
+//	value := dict.Get("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave; el valor nulo si la clave no existe.
+func (d *SortedDictionary[K, V]) Get(key K) V {
+	value, _ := d.TryGet(key)
+	return value
+}
+
+// TryGet devuelve el valor asociado a la clave, o un error si la clave no
+// existe.
+//
+// Uso:
+//
+//	value, err := dict.TryGet("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave y `nil`; el valor nulo y un error que
+//     envuelve `ErrNotFound` si la clave no existe.
+func (d *SortedDictionary[K, V]) TryGet(key K) (V, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for n := d.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, nil
+		}
+	}
+	var zero V
+	return zero, fmt.Errorf("%w: %v", ErrNotFound, key)
+}
+
+// MustGet devuelve el valor asociado a la clave, y entra en pánico si la
+// clave no existe.
+//
+// Uso:
+//
+//	value := dict.MustGet("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave.
+func (d *SortedDictionary[K, V]) MustGet(key K) V {
+	value, err := d.TryGet(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Contains verifica si el diccionario contiene la clave.
+//
+// Uso:
+//
+//	exists := dict.Contains("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - `true` si el diccionario contiene la clave; `false` en caso contrario.
+func (d *SortedDictionary[K, V]) Contains(key K) bool {
+	_, err := d.TryGet(key)
+	return err == nil
+}
+
+// Remove elimina la clave y su valor asociado del diccionario.
+//
+// Uso:
+//
+//	dict.Remove("uno")
+//
+// Parámetros:
+//   - `key`: la clave a eliminar.
+//
+// Retorna:
+//   - `true` si se eliminó la clave; `false` si la clave no existe.
+func (d *SortedDictionary[K, V]) Remove(key K) bool {
+	return d.TryRemove(key) == nil
+}
+
+// TryRemove elimina la clave y su valor asociado del diccionario, o devuelve
+// un error si la clave no existe.
+//
+// Uso:
+//
+//	err := dict.TryRemove("uno")
+//
+// Parámetros:
+//   - `key`: la clave a eliminar.
+//
+// Retorna:
+//   - `nil` si se eliminó la clave; un error que envuelve `ErrNotFound` en
+//     caso contrario.
+func (d *SortedDictionary[K, V]) TryRemove(key K) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var removed bool
+	d.root, removed = remove(d.root, key)
+	if !removed {
+		return fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	d.modCount++
+	return nil
+}
+
+// Min devuelve la menor clave del diccionario y su valor.
+//
+// Uso:
+//
+//	key, value, ok := dict.Min()
+//
+// Retorna:
+//   - la menor clave, su valor y `true`; valores nulos y `false` si el
+//     diccionario está vacío.
+func (d *SortedDictionary[K, V]) Min() (K, V, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	n := d.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return entry(n)
+}
+
+// Max devuelve la mayor clave del diccionario y su valor.
+//
+// Uso:
+//
+//	key, value, ok := dict.Max()
+//
+// Retorna:
+//   - la mayor clave, su valor y `true`; valores nulos y `false` si el
+//     diccionario está vacío.
+func (d *SortedDictionary[K, V]) Max() (K, V, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	n := d.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return entry(n)
+}
+
+// Floor devuelve la mayor clave menor o igual a la dada, y su valor.
+//
+// Uso:
+//
+//	key, value, ok := dict.Floor("m") // La última clave hasta "m".
+//
+// Parámetros:
+//   - `key`: la clave de referencia, que no necesita existir.
+//
+// Retorna:
+//   - la clave encontrada, su valor y `true`; valores nulos y `false` si no
+//     hay claves menores o iguales.
+func (d *SortedDictionary[K, V]) Floor(key K) (K, V, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var found *avlNode[K, V]
+	for n := d.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			found, n = n, n.right
+		default:
+			return entry(n)
+		}
+	}
+	return entry(found)
+}
+
+// Ceiling devuelve la menor clave mayor o igual a la dada, y su valor.
+//
+// Uso:
+//
+//	key, value, ok := dict.Ceiling("m") // La primera clave desde "m".
+//
+// Parámetros:
+//   - `key`: la clave de referencia, que no necesita existir.
+//
+// Retorna:
+//   - la clave encontrada, su valor y `true`; valores nulos y `false` si no
+//     hay claves mayores o iguales.
+func (d *SortedDictionary[K, V]) Ceiling(key K) (K, V, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var found *avlNode[K, V]
+	for n := d.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			found, n = n, n.left
+		case c > 0:
+			n = n.right
+		default:
+			return entry(n)
+		}
+	}
+	return entry(found)
+}
+
+// Range devuelve los pares cuyas claves están entre lo y hi, ambas incluidas,
+// ordenados por clave.
+//
+// Uso:
+//
+//	pairs := dict.Range("b", "d") // Los pares con claves desde "b" hasta "d".
+//
+// Parámetros:
+//   - `lo`: la menor clave del rango.
+//   - `hi`: la mayor clave del rango.
+//
+// Retorna:
+//   - los pares del rango; un slice vacío si lo es mayor que hi.
+func (d *SortedDictionary[K, V]) Range(lo, hi K) []hashtable.Pair[K, V] {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	pairs := []hashtable.Pair[K, V]{}
+	collect(d.root, lo, hi, &pairs)
+	return pairs
+}
+
+// Rank devuelve la cantidad de claves menores que la dada, que es la
+// posición que ocupa, o que ocuparía, en el orden de las claves.
+//
+// Uso:
+//
+//	rank := dict.Rank("c") // Cantidad de claves menores que "c".
+//
+// Parámetros:
+//   - `key`: la clave, que no necesita existir.
+//
+// Retorna:
+//   - la cantidad de claves menores que la dada.
+func (d *SortedDictionary[K, V]) Rank(key K) int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	rank := 0
+	for n := d.root; n != nil; {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += size(n.left) + 1
+			n = n.right
+		default:
+			return rank + size(n.left)
+		}
+	}
+	return rank
+}
+
+// Select devuelve la clave que ocupa la posición dada en el orden de las
+// claves, comenzando en 0, y su valor.
+//
+// Uso:
+//
+//	key, value, ok := dict.Select(0) // Equivale a Min.
+//
+// Parámetros:
+//   - `i`: la posición de la clave.
+//
+// Retorna:
+//   - la clave, su valor y `true`; valores nulos y `false` si la posición no
+//     es válida.
+func (d *SortedDictionary[K, V]) Select(i int) (K, V, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	n := d.root
+	for n != nil {
+		left := size(n.left)
+		switch {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return entry(n)
+		}
+	}
+	return entry[K, V](nil)
+}
+
+// Keys devuelve las claves del diccionario, en orden.
+//
+// Uso:
+//
+//	keys := dict.Keys()
+//
+// Retorna:
+//   - las claves del diccionario como un slice ordenado.
+func (d *SortedDictionary[K, V]) Keys() []K {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	keys := make([]K, 0, size(d.root))
+	inorder(d.root, func(n *avlNode[K, V]) { keys = append(keys, n.key) })
+	return keys
+}
+
+// Values devuelve los valores del diccionario, en el orden de sus claves.
+//
+// Uso:
+//
+//	values := dict.Values()
+//
+// Retorna:
+//   - los valores del diccionario como un slice.
+func (d *SortedDictionary[K, V]) Values() []V {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	values := make([]V, 0, size(d.root))
+	inorder(d.root, func(n *avlNode[K, V]) { values = append(values, n.value) })
+	return values
+}
+
+// Size devuelve la cantidad de claves del diccionario.
+//
+// Uso:
+//
+//	size := dict.Size()
+//
+// Retorna:
+//   - la cantidad de claves del diccionario.
+func (d *SortedDictionary[K, V]) Size() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return size(d.root)
+}
+
+// IsEmpty evalúa si el diccionario está vacío.
+//
+// Uso:
+//
+//	empty := dict.IsEmpty()
+//
+// Retorna:
+//   - `true` si el diccionario está vacío; `false` en caso contrario.
+func (d *SortedDictionary[K, V]) IsEmpty() bool {
+	return d.Size() == 0
+}
+
+// Clear elimina todas las claves del diccionario.
+//
+// Uso:
+//
+//	dict.Clear()
+func (d *SortedDictionary[K, V]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.root = nil
+	d.modCount++
+}
+
+// String devuelve una representación en cadena del diccionario, con las
+// claves en orden.
+//
+// Uso:
+//
+//	fmt.Println(dict) // SortedDictionary: {a: 1, b: 2}
+//
+// Retorna:
+//   - una representación en cadena del diccionario.
+func (d *SortedDictionary[K, V]) String() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	parts := make([]string, 0, size(d.root))
+	inorder(d.root, func(n *avlNode[K, V]) {
+		parts = append(parts, fmt.Sprintf("%v: %v", n.key, n.value))
+	})
+	return "SortedDictionary: {" + strings.Join(parts, ", ") + "}"
+}
+
+// SortedIterator recorre los pares de un SortedDictionary en orden de clave.
+//
+// El iterador es fail-fast: si se agregan o eliminan claves durante la
+// iteración, Next devuelve false y Err devuelve ErrConcurrentModification.
+// Actualizar el valor de una clave existente no lo invalida.
+//
+// Uso:
+//
+//	it := dict.Iterator()
+//	for it.Next() {
+//		fmt.Println(it.Key(), it.Value())
+//	}
+//	if err := it.Err(); err != nil {
+//		// El diccionario se modificó durante la iteración.
+//	}
+type SortedIterator[K cmp.Ordered, V any] struct {
+	// dict es el diccionario que se recorre.
+	dict *SortedDictionary[K, V]
+	// stack son los nodos pendientes cuyo subárbol izquierdo ya se recorrió,
+	// o se va a recorrer antes que ellos.
+	stack []*avlNode[K, V]
+	// current es el nodo actual, o nil si el iterador no está posicionado.
+	current *avlNode[K, V]
+	// expected es el contador de modificaciones esperado en el diccionario.
+	expected uint
+	// err es el error que detuvo la iteración.
+	err error
+}
+
+// Iterator devuelve un iterador posicionado antes de la menor clave.
+func (d *SortedDictionary[K, V]) Iterator() *SortedIterator[K, V] {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	it := &SortedIterator[K, V]{dict: d, expected: d.modCount}
+	it.pushLeft(d.root)
+	return it
+}
+
+// Next avanza a la siguiente clave en orden.
+//
+// Devuelve false si no hay más claves o si el diccionario se modificó durante
+// la iteración (ver Err).
+func (it *SortedIterator[K, V]) Next() bool {
+	it.current = nil
+	if it.err != nil {
+		return false
+	}
+	it.dict.mu.RLock()
+	defer it.dict.mu.RUnlock()
+	if it.dict.modCount != it.expected {
+		it.err = ErrConcurrentModification
+		return false
+	}
+	if len(it.stack) == 0 {
+		return false
+	}
+	it.current = it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	it.pushLeft(it.current.right)
+	return true
+}
+
+// Key devuelve la clave actual.
+//
+// - Si el iterador no está posicionado en una clave, devuelve el valor nulo.
+func (it *SortedIterator[K, V]) Key() K {
+	if it.current == nil {
+		var zero K
+		return zero
+	}
+	return it.current.key
+}
+
+// Value devuelve el valor de la clave actual.
+//
+// - Si el iterador no está posicionado en una clave, devuelve el valor nulo.
+func (it *SortedIterator[K, V]) Value() V {
+	if it.current == nil {
+		var zero V
+		return zero
+	}
+	it.dict.mu.RLock()
+	defer it.dict.mu.RUnlock()
+	return it.current.value
+}
+
+// Err devuelve el error que detuvo la iteración, o nil si la iteración no se
+// detuvo o terminó normalmente.
+func (it *SortedIterator[K, V]) Err() error {
+	return it.err
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// pushLeft apila el nodo y todos sus descendientes por izquierda.
+func (it *SortedIterator[K, V]) pushLeft(n *avlNode[K, V]) {
+	for ; n != nil; n = n.left {
+		it.stack = append(it.stack, n)
+	}
+}
+
+// entry devuelve la clave y el valor del nodo, o valores nulos y false si el
+// nodo es nil.
+func entry[K cmp.Ordered, V any](n *avlNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+// height devuelve la altura del subárbol; 0 si está vacío.
+func height[K cmp.Ordered, V any](n *avlNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// size devuelve la cantidad de nodos del subárbol.
+func size[K cmp.Ordered, V any](n *avlNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update recalcula la altura y el tamaño del nodo a partir de sus hijos.
+func update[K cmp.Ordered, V any](n *avlNode[K, V]) {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.size = 1 + size(n.left) + size(n.right)
+}
+
+// rotateRight rota el subárbol a la derecha y devuelve la nueva raíz.
+func rotateRight[K cmp.Ordered, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	update(n)
+	update(l)
+	return l
+}
+
+// rotateLeft rota el subárbol a la izquierda y devuelve la nueva raíz.
+func rotateLeft[K cmp.Ordered, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	update(n)
+	update(r)
+	return r
+}
+
+// balance actualiza el nodo y, si la diferencia de altura entre sus hijos es
+// mayor a 1, lo rebalancea con una rotación simple o doble. Devuelve la nueva
+// raíz del subárbol.
+func balance[K cmp.Ordered, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	update(n)
+	switch factor := height(n.left) - height(n.right); {
+	case factor > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case factor < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// insert agrega o actualiza el par en el subárbol. Devuelve la nueva raíz del
+// subárbol y si se agregó una clave nueva.
+func insert[K cmp.Ordered, V any](n *avlNode[K, V], key K, value V) (*avlNode[K, V], bool) {
+	if n == nil {
+		return &avlNode[K, V]{key: key, value: value, height: 1, size: 1}, true
+	}
+	var added bool
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left, added = insert(n.left, key, value)
+	case c > 0:
+		n.right, added = insert(n.right, key, value)
+	default:
+		n.value = value
+		return n, false
+	}
+	return balance(n), added
+}
+
+// remove elimina la clave del subárbol. Devuelve la nueva raíz del subárbol y
+// si la clave existía.
+func remove[K cmp.Ordered, V any](n *avlNode[K, V], key K) (*avlNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left, removed = remove(n.left, key)
+	case c > 0:
+		n.right, removed = remove(n.right, key)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// El nodo se reemplaza por su sucesor, el menor del subárbol derecho.
+		var successor *avlNode[K, V]
+		n.right, successor = removeMin(n.right)
+		successor.left, successor.right = n.left, n.right
+		return balance(successor), true
+	}
+	return balance(n), removed
+}
+
+// removeMin quita el menor nodo del subárbol. Devuelve la nueva raíz del
+// subárbol y el nodo quitado.
+func removeMin[K cmp.Ordered, V any](n *avlNode[K, V]) (*avlNode[K, V], *avlNode[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var first *avlNode[K, V]
+	n.left, first = removeMin(n.left)
+	return balance(n), first
+}
+
+// collect agrega a pairs los pares del subárbol con claves entre lo y hi, en
+// orden.
+func collect[K cmp.Ordered, V any](n *avlNode[K, V], lo, hi K, pairs *[]hashtable.Pair[K, V]) {
+	if n == nil {
+		return
+	}
+	if cmp.Less(lo, n.key) {
+		collect(n.left, lo, hi, pairs)
+	}
+	if !cmp.Less(n.key, lo) && !cmp.Less(hi, n.key) {
+		*pairs = append(*pairs, hashtable.Pair[K, V]{Key: n.key, Value: n.value})
+	}
+	if cmp.Less(n.key, hi) {
+		collect(n.right, lo, hi, pairs)
+	}
+}
+
+// inorder aplica la función a los nodos del subárbol en orden de clave.
+func inorder[K cmp.Ordered, V any](n *avlNode[K, V], fn func(*avlNode[K, V])) {
+	if n == nil {
+		return
+	}
+	inorder(n.left, fn)
+	fn(n)
+	inorder(n.right, fn)
+}
@@ -0,0 +1,211 @@
+package dictionary
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// checkAVL verifica que el subárbol esté ordenado, balanceado y con alturas y
+// tamaños correctos, y devuelve su altura.
+func checkAVL[K cmp.Ordered, V any](t *testing.T, n *avlNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	if n.left != nil {
+		require.Less(t, n.left.key, n.key)
+	}
+	if n.right != nil {
+		require.Greater(t, n.right.key, n.key)
+	}
+	l, r := checkAVL(t, n.left), checkAVL(t, n.right)
+	require.LessOrEqual(t, max(l-r, r-l), 1)
+	require.Equal(t, 1+max(l, r), n.height)
+	require.Equal(t, 1+size(n.left)+size(n.right), n.size)
+	return n.height
+}
+
+func TestNewSortedDictionary(t *testing.T) {
+	dict := NewSortedDictionary[string, int]()
+
+	assert.True(t, dict.IsEmpty())
+	assert.Equal(t, "SortedDictionary: {}", dict.String())
+	_, _, ok := dict.Min()
+	assert.False(t, ok)
+	_, _, ok = dict.Max()
+	assert.False(t, ok)
+}
+
+func TestSortedDictionaryPutGetRemove(t *testing.T) {
+	dict := NewSortedDictionary[string, int]()
+
+	assert.True(t, dict.Put("dos", 2))
+	assert.True(t, dict.Put("uno", 1))
+	assert.True(t, dict.Put("tres", 3))
+	assert.True(t, dict.Put("uno", 10))
+
+	assert.Equal(t, 3, dict.Size())
+	assert.Equal(t, 10, dict.Get("uno"))
+	assert.Equal(t, 10, dict.MustGet("uno"))
+	assert.Equal(t, 0, dict.Get("cuatro"))
+	assert.True(t, dict.Contains("dos"))
+	_, err := dict.TryGet("cuatro")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Panics(t, func() { dict.MustGet("cuatro") })
+
+	assert.Equal(t, []string{"dos", "tres", "uno"}, dict.Keys())
+	assert.Equal(t, []int{2, 3, 10}, dict.Values())
+	assert.Equal(t, "SortedDictionary: {dos: 2, tres: 3, uno: 10}", dict.String())
+
+	assert.True(t, dict.Remove("tres"))
+	assert.False(t, dict.Remove("tres"))
+	assert.ErrorIs(t, dict.TryRemove("tres"), ErrNotFound)
+	assert.Equal(t, []string{"dos", "uno"}, dict.Keys())
+
+	dict.Clear()
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestSortedDictionaryClaveInvalida(t *testing.T) {
+	dict := NewSortedDictionary[float64, string]()
+
+	assert.ErrorIs(t, dict.TryPut(math.NaN(), "nan"), ErrInvalidKey)
+	assert.False(t, dict.Put(math.NaN(), "nan"))
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestSortedDictionaryBalanceado(t *testing.T) {
+	dict := NewSortedDictionary[int, int]()
+	keys := rand.Perm(1000)
+	for _, k := range keys {
+		dict.Put(k, k)
+	}
+	checkAVL(t, dict.root)
+	// Un árbol AVL de n nodos tiene altura menor a 1.45 log2(n+2).
+	assert.Less(t, float64(dict.root.height), 1.45*math.Log2(1002))
+
+	for _, k := range keys[:700] {
+		require.True(t, dict.Remove(k))
+	}
+	checkAVL(t, dict.root)
+
+	expected := slices.Sorted(slices.Values(keys[700:]))
+	assert.Equal(t, expected, dict.Keys())
+}
+
+func TestSortedDictionaryMinMaxFloorCeiling(t *testing.T) {
+	dict := NewSortedDictionary[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		dict.Put(k, "v")
+	}
+
+	k, _, _ := dict.Min()
+	assert.Equal(t, 10, k)
+	k, _, _ = dict.Max()
+	assert.Equal(t, 40, k)
+
+	k, _, ok := dict.Floor(25)
+	assert.True(t, ok)
+	assert.Equal(t, 20, k)
+	k, _, _ = dict.Floor(30)
+	assert.Equal(t, 30, k)
+	_, _, ok = dict.Floor(5)
+	assert.False(t, ok)
+
+	k, _, ok = dict.Ceiling(25)
+	assert.True(t, ok)
+	assert.Equal(t, 30, k)
+	k, _, _ = dict.Ceiling(10)
+	assert.Equal(t, 10, k)
+	_, _, ok = dict.Ceiling(45)
+	assert.False(t, ok)
+}
+
+func TestSortedDictionaryRange(t *testing.T) {
+	// Claves con formato "día hora" convertidas a un número que respeta el
+	// orden de la semana.
+	dias := map[string]int{"Lun": 1, "Mar": 2, "Mie": 3, "Jue": 4, "Vie": 5}
+	dict := NewSortedDictionary[int, string]()
+	for _, turno := range []struct {
+		dia  string
+		hora int
+	}{{"Mie", 10}, {"Vie", 12}, {"Lun", 9}, {"Jue", 18}} {
+		dict.Put(dias[turno.dia]*100+turno.hora, turno.dia)
+	}
+
+	assert.Equal(t, []hashtable.Pair[int, string]{
+		{Key: 310, Value: "Mie"},
+		{Key: 418, Value: "Jue"},
+	}, dict.Range(300, 499))
+	assert.Len(t, dict.Range(0, 1000), 4)
+	assert.Empty(t, dict.Range(600, 700))
+	assert.Empty(t, dict.Range(499, 300))
+	assert.Equal(t, []hashtable.Pair[int, string]{{Key: 512, Value: "Vie"}}, dict.Range(512, 512))
+}
+
+func TestSortedDictionaryRankSelect(t *testing.T) {
+	dict := NewSortedDictionary[int, int]()
+	for k := 0; k < 100; k += 2 {
+		dict.Put(k, k*10)
+	}
+
+	assert.Equal(t, 0, dict.Rank(0))
+	assert.Equal(t, 5, dict.Rank(10))
+	assert.Equal(t, 6, dict.Rank(11))
+	assert.Equal(t, 50, dict.Rank(1000))
+
+	for i := range 50 {
+		k, v, ok := dict.Select(i)
+		require.True(t, ok)
+		assert.Equal(t, 2*i, k)
+		assert.Equal(t, 20*i, v)
+		assert.Equal(t, i, dict.Rank(k))
+	}
+	_, _, ok := dict.Select(50)
+	assert.False(t, ok)
+	_, _, ok = dict.Select(-1)
+	assert.False(t, ok)
+}
+
+func TestSortedDictionaryIterator(t *testing.T) {
+	dict := NewSortedDictionary[string, int]()
+	dict.Put("c", 3)
+	dict.Put("a", 1)
+	dict.Put("b", 2)
+
+	var keys []string
+	var values []int
+	it := dict.Iterator()
+	for it.Next() {
+		keys = append(keys, it.Key())
+		values = append(values, it.Value())
+		// Actualizar el valor no invalida el iterador.
+		dict.Put(it.Key(), it.Value()*10)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Equal(t, []int{1, 2, 3}, values)
+	assert.Equal(t, []int{10, 20, 30}, dict.Values())
+	assert.Equal(t, "", it.Key())
+}
+
+func TestSortedDictionaryIteratorFailFast(t *testing.T) {
+	dict := NewSortedDictionary[int, int]()
+	for k := range 10 {
+		dict.Put(k, k)
+	}
+
+	it := dict.Iterator()
+	require.True(t, it.Next())
+	dict.Remove(5)
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+	assert.False(t, it.Next())
+}
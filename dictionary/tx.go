@@ -0,0 +1,414 @@
+package dictionary
+
+import (
+	"errors"
+	"fmt"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+var (
+	// ErrTxDone indica que la transacción ya se confirmó o se descartó.
+	ErrTxDone = errors.New("dictionary: la transacción ya terminó")
+	// ErrInvalidSavepoint indica que el punto de guardado no existe en la
+	// transacción, por ejemplo porque ya se liberó o se volvió a uno anterior.
+	ErrInvalidSavepoint = errors.New("dictionary: punto de guardado inválido")
+)
+
+// write es una modificación registrada en una transacción.
+type write[V any] struct {
+	// value es el nuevo valor de la clave, si no se eliminó.
+	value V
+	// deleted indica si la clave se eliminó.
+	deleted bool
+}
+
+// Savepoint identifica un punto de guardado dentro de una transacción.
+//
+// Un punto de guardado que dejó de existir no vuelve a ser válido, aunque
+// luego se cree otro en el mismo nivel.
+type Savepoint struct {
+	// level es el nivel de modificaciones que abrió el punto de guardado.
+	level int
+	// generation distingue al punto de guardado de otros creados en el mismo
+	// nivel.
+	generation uint64
+}
+
+// Tx es una transacción sobre un diccionario. Las modificaciones realizadas en
+// la transacción no se ven en el diccionario hasta confirmarla con Commit, y
+// se aplican todas juntas o ninguna. Las lecturas de la transacción ven sus
+// propias modificaciones.
+//
+// La transacción no aísla de las modificaciones realizadas directamente sobre
+// el diccionario mientras está abierta: sus lecturas las ven, y al confirmar
+// se sobrescriben las claves que la transacción modificó.
+//
+// Una transacción no es segura para uso concurrente, pero pueden usarse
+// varias transacciones sobre el mismo diccionario desde distintas goroutines.
+//
+// Los puntos de guardado permiten descartar solo una parte de las
+// modificaciones. Cada punto de guardado abre un nivel de modificaciones: se
+// pueden descartar con RollbackTo o incorporar al nivel anterior con Release.
+type Tx[K comparable, V any] struct {
+	// dict es el diccionario sobre el que se realiza la transacción.
+	dict *Dictionary[K, V]
+	// levels son las modificaciones de cada nivel: el primero corresponde a la
+	// transacción y cada uno de los siguientes a un punto de guardado.
+	levels []*hashtable.HashTable[K, write[V]]
+	// generations contiene la generación de cada nivel.
+	generations []uint64
+	// generation es la generación del último nivel creado.
+	generation uint64
+	// done indica si la transacción ya terminó.
+	done bool
+}
+
+// Begin inicia una transacción sobre el diccionario.
+//
+// Uso:
+//
+//	tx := dict.Begin()
+//	tx.Put("uno", 1)
+//	tx.Remove("dos")
+//	err := tx.Commit() // Aplica ambas modificaciones, o ninguna.
+//
+// Retorna:
+//   - la nueva transacción.
+func (d *Dictionary[K, V]) Begin() *Tx[K, V] {
+	tx := &Tx[K, V]{dict: d}
+	tx.push()
+	return tx
+}
+
+// Put agrega o actualiza un par clave-valor en la transacción.
+//
+// Uso:
+//
+//	ok, err := tx.Put("uno", 1)
+//
+// Parámetros:
+//   - `key`: la clave.
+//   - `value`: el valor a asociar a la clave.
+//
+// Retorna:
+//   - `true` y `nil` si se registró el par; `false` y un error que envuelve
+//     `ErrTxDone` o `ErrInvalidKey` en caso contrario.
+func (tx *Tx[K, V]) Put(key K, value V) (bool, error) {
+	if tx.done {
+		return false, ErrTxDone
+	}
+	if err := tx.top().TryPut(key, write[V]{value: value}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get devuelve el valor asociado a la clave, teniendo en cuenta las
+// modificaciones de la transacción.
+//
+// Uso:
+//
+//	value := tx.Get("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave; el valor nulo si la clave no existe.
+func (tx *Tx[K, V]) Get(key K) V {
+	value, _ := tx.lookup(key)
+	return value
+}
+
+// TryGet devuelve el valor asociado a la clave, teniendo en cuenta las
+// modificaciones de la transacción, o un error si la clave no existe.
+//
+// Uso:
+//
+//	value, err := tx.TryGet("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - el valor asociado a la clave y `nil`; el valor nulo y un error que
+//     envuelve `ErrNotFound` si la clave no existe.
+func (tx *Tx[K, V]) TryGet(key K) (V, error) {
+	value, ok := tx.lookup(key)
+	if !ok {
+		return value, fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	return value, nil
+}
+
+// Contains verifica si la clave existe, teniendo en cuenta las modificaciones
+// de la transacción.
+//
+// Uso:
+//
+//	exists := tx.Contains("uno")
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//
+// Retorna:
+//   - `true` si la clave existe; `false` en caso contrario.
+func (tx *Tx[K, V]) Contains(key K) bool {
+	_, ok := tx.lookup(key)
+	return ok
+}
+
+// Remove elimina la clave en la transacción.
+//
+// Uso:
+//
+//	ok, err := tx.Remove("uno")
+//
+// Parámetros:
+//   - `key`: la clave a eliminar.
+//
+// Retorna:
+//   - `true` si la clave existía; `false` si no existía. El error envuelve
+//     `ErrTxDone` si la transacción ya terminó.
+func (tx *Tx[K, V]) Remove(key K) (bool, error) {
+	if tx.done {
+		return false, ErrTxDone
+	}
+	if !tx.Contains(key) {
+		return false, nil
+	}
+	tx.top().Put(key, write[V]{deleted: true})
+	return true, nil
+}
+
+// Savepoint crea un punto de guardado. Las modificaciones posteriores pueden
+// descartarse con RollbackTo sin descartar las anteriores.
+//
+// Uso:
+//
+//	sp, _ := tx.Savepoint()
+//	tx.Put("uno", 10)
+//	tx.RollbackTo(sp) // Descarta solo el último Put.
+//
+// Retorna:
+//   - el punto de guardado; un error que envuelve `ErrTxDone` si la
+//     transacción ya terminó.
+func (tx *Tx[K, V]) Savepoint() (Savepoint, error) {
+	if tx.done {
+		return Savepoint{}, ErrTxDone
+	}
+	tx.push()
+	return Savepoint{level: len(tx.levels) - 1, generation: tx.generation}, nil
+}
+
+// RollbackTo descarta las modificaciones realizadas desde el punto de
+// guardado, incluidas las de los puntos de guardado posteriores, que dejan de
+// existir. El punto de guardado sigue existiendo.
+//
+// Uso:
+//
+//	err := tx.RollbackTo(sp)
+//
+// Parámetros:
+//   - `sp`: el punto de guardado.
+//
+// Retorna:
+//   - `nil` si se descartaron las modificaciones; un error que envuelve
+//     `ErrTxDone` o `ErrInvalidSavepoint` en caso contrario.
+func (tx *Tx[K, V]) RollbackTo(sp Savepoint) error {
+	if err := tx.checkSavepoint(sp); err != nil {
+		return err
+	}
+	tx.levels = tx.levels[:sp.level]
+	tx.generations = tx.generations[:sp.level]
+	tx.push()
+	tx.generations[sp.level] = sp.generation
+	return nil
+}
+
+// Release libera el punto de guardado, incorporando sus modificaciones, y las
+// de los puntos de guardado posteriores, al nivel anterior. Luego, ya no
+// pueden descartarse por separado.
+//
+// Uso:
+//
+//	err := tx.Release(sp)
+//
+// Parámetros:
+//   - `sp`: el punto de guardado.
+//
+// Retorna:
+//   - `nil` si se liberó el punto de guardado; un error que envuelve
+//     `ErrTxDone` o `ErrInvalidSavepoint` en caso contrario.
+func (tx *Tx[K, V]) Release(sp Savepoint) error {
+	if err := tx.checkSavepoint(sp); err != nil {
+		return err
+	}
+	for _, level := range tx.levels[sp.level:] {
+		for it := level.Iterator(); it.Next(); {
+			tx.levels[sp.level-1].Put(it.Key(), it.Value())
+		}
+	}
+	tx.levels = tx.levels[:sp.level]
+	tx.generations = tx.generations[:sp.level]
+	return nil
+}
+
+// Commit aplica al diccionario todas las modificaciones de la transacción y
+// la termina.
+//
+// Si alguna modificación no puede aplicarse, por ejemplo porque el diccionario
+// alcanzó su capacidad máxima, se deshacen las ya aplicadas y el diccionario
+// queda como estaba. En ese caso la transacción sigue abierta.
+//
+// Las modificaciones se aplican con el diccionario bloqueado, por lo que
+// ninguna lectura ve un estado intermedio. Luego se publica un evento por
+// cada clave modificada (ver Subscribe).
+//
+// Uso:
+//
+//	if err := tx.Commit(); err != nil {
+//		fmt.Println("No se aplicó ninguna modificación:", err)
+//	}
+//
+// Retorna:
+//   - `nil` si se aplicaron las modificaciones; un error que envuelve
+//     `ErrTxDone` o `ErrCapacityExceeded` en caso contrario.
+func (tx *Tx[K, V]) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	defer tx.dict.feed.flush()
+	tx.dict.mu.Lock()
+	defer tx.dict.mu.Unlock()
+	writes := tx.merge()
+
+	// undo registra el estado anterior de cada clave modificada, para poder
+	// deshacer los cambios si alguno falla.
+	type undo struct {
+		key     K
+		value   V
+		existed bool
+	}
+	applied := make([]undo, 0, writes.Size())
+	var err error
+	for it := writes.Iterator(); it.Next(); {
+		key, w := it.Key(), it.Value()
+		old, getErr := tx.dict.hash.TryGet(key)
+		applied = append(applied, undo{key: key, value: old, existed: getErr == nil})
+		if w.deleted {
+			tx.dict.hash.Remove(key)
+		} else if err = tx.dict.hash.TryPut(key, w.value); err != nil {
+			applied = applied[:len(applied)-1]
+			break
+		}
+	}
+	if err != nil {
+		for i := len(applied) - 1; i >= 0; i-- {
+			if applied[i].existed {
+				tx.dict.hash.Put(applied[i].key, applied[i].value)
+			} else {
+				tx.dict.hash.Remove(applied[i].key)
+			}
+		}
+		return err
+	}
+	for i, it := 0, writes.Iterator(); it.Next(); i++ {
+		if w := it.Value(); !w.deleted {
+			tx.dict.recordPut(applied[i].key, w.value)
+		} else if applied[i].existed {
+			tx.dict.recordRemove(applied[i].key)
+		}
+	}
+	if tx.dict.feed.active() {
+		for i, it := 0, writes.Iterator(); it.Next(); i++ {
+			u, w := applied[i], it.Value()
+			switch {
+			case !w.deleted:
+				tx.dict.feed.publish(Event[K, V]{Kind: EventPut, Key: u.key, OldValue: u.value, NewValue: w.value, Replaced: u.existed})
+			case u.existed:
+				tx.dict.feed.publish(Event[K, V]{Kind: EventRemove, Key: u.key, OldValue: u.value})
+			}
+		}
+	}
+	tx.finish()
+	return nil
+}
+
+// Rollback descarta todas las modificaciones de la transacción y la termina.
+//
+// Uso:
+//
+//	tx.Rollback() // El diccionario queda como estaba.
+//
+// Retorna:
+//   - `nil` si se descartaron las modificaciones; `ErrTxDone` si la
+//     transacción ya terminó.
+func (tx *Tx[K, V]) Rollback() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.finish()
+	return nil
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// push agrega un nivel de modificaciones vacío, con una nueva generación.
+func (tx *Tx[K, V]) push() {
+	tx.dict.mu.RLock()
+	defer tx.dict.mu.RUnlock()
+	tx.levels = append(tx.levels, hashtable.NewHashTableLike[write[V]](tx.dict.hash))
+	tx.generation++
+	tx.generations = append(tx.generations, tx.generation)
+}
+
+// top devuelve el nivel de modificaciones actual.
+func (tx *Tx[K, V]) top() *hashtable.HashTable[K, write[V]] {
+	return tx.levels[len(tx.levels)-1]
+}
+
+// lookup busca la clave en los niveles de modificaciones, del más reciente al
+// más antiguo, y luego en el diccionario.
+func (tx *Tx[K, V]) lookup(key K) (V, bool) {
+	for i := len(tx.levels) - 1; i >= 0; i-- {
+		if w, ok := tx.levels[i].Get(key); ok {
+			return w.value, !w.deleted
+		}
+	}
+	value, err := tx.dict.TryGet(key)
+	return value, err == nil
+}
+
+// merge devuelve las modificaciones de todos los niveles combinadas, donde
+// cada clave tiene su modificación más reciente.
+func (tx *Tx[K, V]) merge() *hashtable.HashTable[K, write[V]] {
+	if len(tx.levels) == 1 {
+		return tx.levels[0]
+	}
+	merged := hashtable.NewHashTableLike[write[V]](tx.dict.hash)
+	for _, level := range tx.levels {
+		merged.PutAll(level)
+	}
+	return merged
+}
+
+// checkSavepoint verifica que la transacción esté abierta y que el punto de
+// guardado exista: que su nivel exista y tenga la misma generación.
+func (tx *Tx[K, V]) checkSavepoint(sp Savepoint) error {
+	if tx.done {
+		return ErrTxDone
+	}
+	if sp.level < 1 || sp.level >= len(tx.levels) || tx.generations[sp.level] != sp.generation {
+		return fmt.Errorf("%w: %d", ErrInvalidSavepoint, sp.level)
+	}
+	return nil
+}
+
+// finish termina la transacción y descarta sus modificaciones.
+func (tx *Tx[K, V]) finish() {
+	tx.done = true
+	tx.levels = nil
+	tx.generations = nil
+}
@@ -0,0 +1,207 @@
+package dictionary
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxLeeSusPropiasEscrituras(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.Put("dos", 2)
+
+	tx := dict.Begin()
+	tx.Put("uno", 10)
+	tx.Put("tres", 3)
+	tx.Remove("dos")
+
+	assert.Equal(t, 10, tx.Get("uno"))
+	assert.Equal(t, 3, tx.Get("tres"))
+	assert.False(t, tx.Contains("dos"))
+	_, err := tx.TryGet("dos")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// El diccionario no cambia hasta confirmar.
+	assert.Equal(t, 1, dict.Get("uno"))
+	assert.True(t, dict.Contains("dos"))
+	assert.False(t, dict.Contains("tres"))
+}
+
+func TestTxCommit(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.Put("dos", 2)
+
+	tx := dict.Begin()
+	tx.Put("uno", 10)
+	tx.Put("tres", 3)
+	tx.Remove("dos")
+	require.NoError(t, tx.Commit())
+
+	assert.Equal(t, 2, dict.Size())
+	assert.Equal(t, 10, dict.Get("uno"))
+	assert.Equal(t, 3, dict.Get("tres"))
+	assert.False(t, dict.Contains("dos"))
+}
+
+func TestTxRollback(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	tx := dict.Begin()
+	tx.Put("uno", 10)
+	tx.Remove("uno")
+	require.NoError(t, tx.Rollback())
+
+	assert.Equal(t, 1, dict.Get("uno"))
+	assert.Equal(t, 1, dict.Size())
+}
+
+func TestTxTerminada(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	tx := dict.Begin()
+	require.NoError(t, tx.Commit())
+
+	_, err := tx.Put("uno", 1)
+	assert.ErrorIs(t, err, ErrTxDone)
+	_, err = tx.Remove("uno")
+	assert.ErrorIs(t, err, ErrTxDone)
+	_, err = tx.Savepoint()
+	assert.ErrorIs(t, err, ErrTxDone)
+	assert.ErrorIs(t, tx.Commit(), ErrTxDone)
+	assert.ErrorIs(t, tx.Rollback(), ErrTxDone)
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestTxRemoveClaveInexistente(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	tx := dict.Begin()
+
+	ok, err := tx.Remove("uno")
+	assert.False(t, ok)
+	assert.NoError(t, err)
+
+	tx.Put("uno", 1)
+	ok, _ = tx.Remove("uno")
+	assert.True(t, ok)
+	require.NoError(t, tx.Commit())
+	assert.True(t, dict.IsEmpty())
+}
+
+func TestTxClaveInvalida(t *testing.T) {
+	dict := NewDictionary[float64, string]()
+	tx := dict.Begin()
+
+	ok, err := tx.Put(math.NaN(), "nan")
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestTxSavepoints(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	tx := dict.Begin()
+	tx.Put("dos", 2)
+	sp1, err := tx.Savepoint()
+	require.NoError(t, err)
+	tx.Put("uno", 10)
+	sp2, _ := tx.Savepoint()
+	tx.Remove("dos")
+	assert.False(t, tx.Contains("dos"))
+
+	require.NoError(t, tx.RollbackTo(sp2))
+	assert.Equal(t, 2, tx.Get("dos"))
+	assert.Equal(t, 10, tx.Get("uno"))
+
+	// Tras volver a sp1, sp2 deja de existir.
+	require.NoError(t, tx.RollbackTo(sp1))
+	assert.Equal(t, 1, tx.Get("uno"))
+	assert.ErrorIs(t, tx.RollbackTo(sp2), ErrInvalidSavepoint)
+
+	// sp1 sigue existiendo.
+	tx.Put("tres", 3)
+	require.NoError(t, tx.RollbackTo(sp1))
+	assert.False(t, tx.Contains("tres"))
+
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, 2, dict.Size())
+	assert.Equal(t, 1, dict.Get("uno"))
+	assert.Equal(t, 2, dict.Get("dos"))
+}
+
+func TestTxRelease(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	tx := dict.Begin()
+	sp1, _ := tx.Savepoint()
+	tx.Put("dos", 2)
+	sp2, _ := tx.Savepoint()
+	tx.Remove("uno")
+
+	require.NoError(t, tx.Release(sp2))
+	assert.ErrorIs(t, tx.Release(sp2), ErrInvalidSavepoint)
+	assert.False(t, tx.Contains("uno"))
+
+	require.NoError(t, tx.Release(sp1))
+	assert.ErrorIs(t, tx.RollbackTo(sp1), ErrInvalidSavepoint)
+	assert.ErrorIs(t, tx.RollbackTo(Savepoint{}), ErrInvalidSavepoint)
+
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, []string{"dos"}, dict.Keys())
+}
+
+func TestTxSavepointReutilizado(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	tx := dict.Begin()
+	sp1, _ := tx.Savepoint()
+	sp2, _ := tx.Savepoint()
+	tx.Put("uno", 1)
+
+	// Tras volver a sp1 se crea otro punto de guardado en el mismo nivel que
+	// sp2, pero sp2 sigue siendo inválido.
+	require.NoError(t, tx.RollbackTo(sp1))
+	sp3, _ := tx.Savepoint()
+	tx.Put("dos", 2)
+	assert.ErrorIs(t, tx.RollbackTo(sp2), ErrInvalidSavepoint)
+	assert.ErrorIs(t, tx.Release(sp2), ErrInvalidSavepoint)
+	assert.True(t, tx.Contains("dos"))
+
+	// Lo mismo ocurre tras liberarlo.
+	require.NoError(t, tx.Release(sp3))
+	sp4, _ := tx.Savepoint()
+	assert.ErrorIs(t, tx.RollbackTo(sp3), ErrInvalidSavepoint)
+	require.NoError(t, tx.RollbackTo(sp4))
+	require.NoError(t, tx.RollbackTo(sp1))
+	assert.False(t, tx.Contains("dos"))
+}
+
+func TestTxCommitAtomico(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	dict.SetMaxCapacity(17)
+	for i := range 5 {
+		dict.Put(i, i)
+	}
+
+	tx := dict.Begin()
+	for i := range 5 {
+		tx.Remove(i)
+	}
+	for i := 100; i < 150; i++ {
+		tx.Put(i, i)
+	}
+
+	assert.ErrorIs(t, tx.Commit(), ErrCapacityExceeded)
+	assert.Equal(t, 5, dict.Size())
+	for i := range 5 {
+		assert.Equal(t, i, dict.MustGet(i))
+	}
+	assert.False(t, dict.Contains(100))
+
+	// La transacción sigue abierta y puede descartarse.
+	assert.NoError(t, tx.Rollback())
+}
@@ -0,0 +1,148 @@
+package dictionary
+
+import (
+	"errors"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// ErrNotVersioned indica que se pidió una versión o un snapshot a un
+// diccionario que no registra versiones (ver EnableVersioning).
+var ErrNotVersioned = errors.New("dictionary: el diccionario no registra versiones")
+
+// EnableVersioning activa el modo multiversión (MVCC) del diccionario: desde
+// entonces, cada escritura crea una nueva versión, que puede leerse con GetAt
+// o fijarse con Snapshot mientras se siguen realizando escrituras.
+//
+// El contenido del diccionario al activarlo es la versión 0. Las versiones se
+// registran en un MVCCDictionary, por lo que valen las mismas reglas de
+// descarte. Las escrituras realizadas con transacciones o con un
+// DefaultDictionary sobre el diccionario también crean versiones, una por
+// clave modificada.
+//
+// Si el modo ya estaba activo, solo cambia la ventana de retención.
+//
+// Uso:
+//
+//	dict := dictionary.NewDictionary[string, int]()
+//	dict.EnableVersioning(0)
+//	dict.Put("uno", 1)
+//	snap, _ := dict.Snapshot()
+//	defer snap.Release()
+//	dict.Put("uno", 10)
+//	snap.Get("uno") // Devuelve 1.
+//
+// Parámetros:
+//   - `retention`: la cantidad de versiones anteriores a la actual que pueden
+//     leerse con GetAt aunque no haya snapshots que las usen (ver
+//     MVCCDictionary.SetRetention).
+func (d *Dictionary[K, V]) EnableVersioning(retention Version) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.versions == nil {
+		d.versions = newMVCCDictionaryFrom(d.hash)
+	}
+	d.versions.SetRetention(retention)
+}
+
+// Version devuelve la versión actual del diccionario.
+//
+// Uso:
+//
+//	v := dict.Version()
+//
+// Retorna:
+//   - la versión actual; 0 si el diccionario no registra versiones.
+func (d *Dictionary[K, V]) Version() Version {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.versions == nil {
+		return 0
+	}
+	return d.versions.Version()
+}
+
+// GetAt devuelve el valor asociado a la clave en una versión anterior.
+//
+// Uso:
+//
+//	value, err := dict.GetAt("uno", v)
+//
+// Parámetros:
+//   - `key`: la clave a buscar.
+//   - `version`: la versión a leer.
+//
+// Retorna:
+//   - el valor asociado a la clave en esa versión y `nil`; el valor nulo y un
+//     error que envuelve `ErrNotVersioned` si el diccionario no registra
+//     versiones, o los mismos errores que MVCCDictionary.GetAt.
+func (d *Dictionary[K, V]) GetAt(key K, version Version) (V, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.versions == nil {
+		var zero V
+		return zero, ErrNotVersioned
+	}
+	return d.versions.GetAt(key, version)
+}
+
+// Snapshot devuelve una vista de solo lectura del diccionario fijada en la
+// versión actual. La vista no cambia aunque se sigan realizando escrituras, y
+// debe liberarse con Release.
+//
+// Uso:
+//
+//	snap, err := dict.Snapshot()
+//	if err == nil {
+//		defer snap.Release()
+//	}
+//
+// Retorna:
+//   - el snapshot de la versión actual y `nil`; `nil` y `ErrNotVersioned` si
+//     el diccionario no registra versiones.
+func (d *Dictionary[K, V]) Snapshot() (*Snapshot[K, V], error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.versions == nil {
+		return nil, ErrNotVersioned
+	}
+	return d.versions.Snapshot(), nil
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// newMVCCDictionaryFrom crea un diccionario multiversión cuya versión 0 es el
+// contenido de la tabla, y que compara las claves como ella.
+func newMVCCDictionaryFrom[K comparable, V any](hash *hashtable.HashTable[K, V]) *MVCCDictionary[K, V] {
+	d := NewMVCCDictionary[K, V]()
+	d.history = hashtable.NewHashTableLike[[]revision[V]](hash)
+	for it := hash.Iterator(); it.Next(); {
+		d.history.Put(it.Key(), []revision[V]{{value: it.Value()}})
+		d.size++
+	}
+	return d
+}
+
+// recordPut registra en las versiones que se agregó el par. Debe llamarse con
+// el lock de escritura tomado.
+func (d *Dictionary[K, V]) recordPut(key K, value V) {
+	if d.versions != nil {
+		d.versions.Put(key, value)
+	}
+}
+
+// recordRemove registra en las versiones que se eliminó la clave. Debe
+// llamarse con el lock de escritura tomado.
+func (d *Dictionary[K, V]) recordRemove(key K) {
+	if d.versions != nil {
+		d.versions.Remove(key)
+	}
+}
+
+// recordClear registra en las versiones que se vació el diccionario. Debe
+// llamarse con el lock de escritura tomado.
+func (d *Dictionary[K, V]) recordClear() {
+	if d.versions != nil {
+		d.versions.Clear()
+	}
+}
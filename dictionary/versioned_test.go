@@ -0,0 +1,174 @@
+package dictionary
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+func TestDictionarySinVersiones(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+
+	assert.Equal(t, Version(0), dict.Version())
+	_, err := dict.GetAt("uno", 0)
+	assert.ErrorIs(t, err, ErrNotVersioned)
+	snap, err := dict.Snapshot()
+	assert.ErrorIs(t, err, ErrNotVersioned)
+	assert.Nil(t, snap)
+}
+
+func TestDictionaryVersiones(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.EnableVersioning(10)
+
+	// El contenido al activar las versiones es la versión 0.
+	assert.Equal(t, Version(0), dict.Version())
+	dict.Put("dos", 2)
+	dict.Put("uno", 10)
+	dict.Remove("dos")
+	dict.Remove("tres")
+	assert.Equal(t, Version(3), dict.Version())
+
+	for _, tc := range []struct {
+		version Version
+		uno     int
+		dos     bool
+	}{
+		{0, 1, false},
+		{1, 1, true},
+		{2, 10, true},
+		{3, 10, false},
+	} {
+		v, err := dict.GetAt("uno", tc.version)
+		require.NoError(t, err)
+		assert.Equal(t, tc.uno, v, "versión %d", tc.version)
+		_, err = dict.GetAt("dos", tc.version)
+		assert.Equal(t, tc.dos, err == nil, "versión %d", tc.version)
+	}
+	_, err := dict.GetAt("uno", 4)
+	assert.ErrorIs(t, err, ErrFutureVersion)
+
+	dict.Clear()
+	assert.Equal(t, Version(4), dict.Version())
+	_, err = dict.GetAt("uno", 4)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDictionarySnapshot(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.EnableVersioning(0)
+	dict.Put("uno", 1)
+	dict.Put("dos", 2)
+
+	snap, err := dict.Snapshot()
+	require.NoError(t, err)
+	dict.Put("uno", 10)
+	dict.Remove("dos")
+	dict.Put("tres", 3)
+
+	assert.Equal(t, Version(2), snap.Version())
+	assert.Equal(t, 1, snap.Get("uno"))
+	assert.Equal(t, 2, snap.Get("dos"))
+	assert.False(t, snap.Contains("tres"))
+	assert.Equal(t, 10, dict.Get("uno"))
+
+	// Al liberar el snapshot, su versión se descarta.
+	snap.Release()
+	_, err = dict.GetAt("uno", 2)
+	assert.ErrorIs(t, err, ErrVersionCollected)
+}
+
+func TestDictionaryVersionesTransaccionesYDefault(t *testing.T) {
+	dict := NewDictionary[string, int]()
+	dict.Put("uno", 1)
+	dict.EnableVersioning(100)
+
+	tx := dict.Begin()
+	tx.Put("dos", 2)
+	tx.Remove("uno")
+	tx.Remove("tres")
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, Version(2), dict.Version())
+	_, err := dict.GetAt("uno", 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Una transacción que no se aplica no crea versiones.
+	dict.SetMaxCapacity(dict.hash.Capacity())
+	tx = dict.Begin()
+	for i := range 100 {
+		tx.Put(fmt.Sprint(i), i)
+	}
+	assert.ErrorIs(t, tx.Commit(), ErrCapacityExceeded)
+	assert.Equal(t, Version(2), dict.Version())
+
+	counts := &DefaultDictionary[string, int]{dict: dict, factory: func() int { return 0 }}
+	counts.Update("dos", func(n *int) { *n++ })
+	assert.Equal(t, Version(3), dict.Version())
+	v, err := dict.GetAt("dos", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestDictionaryVersionesNormalizadas(t *testing.T) {
+	dict := NewNormalizedDictionary[int](hashtable.FoldCase)
+	dict.Put("Uno", 1)
+	dict.EnableVersioning(10)
+	dict.Put("UNO", 10)
+
+	v, err := dict.GetAt("uno", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, err = dict.GetAt("uNo", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 10, v)
+}
+
+func TestDictionarySnapshotsConcurrentes(t *testing.T) {
+	dict := NewDictionary[int, int]()
+	for i := range 10 {
+		dict.Put(i, 0)
+	}
+	dict.EnableVersioning(0)
+
+	// Cada transacción asigna el mismo valor a todas las claves. Como un
+	// snapshot no puede tomarse en medio de una confirmación, en cada
+	// snapshot todas las claves tienen el mismo valor.
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 50 {
+				tx := dict.Begin()
+				for i := range 10 {
+					tx.Put(i, 100*w+j)
+				}
+				assert.NoError(t, tx.Commit())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				snap, err := dict.Snapshot()
+				if !assert.NoError(t, err) {
+					return
+				}
+				values := snap.Values()
+				assert.Len(t, values, 10)
+				for _, v := range values {
+					assert.Equal(t, values[0], v)
+				}
+				snap.Release()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, Version(4*50*10), dict.Version())
+}
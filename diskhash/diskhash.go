@@ -0,0 +1,496 @@
+// diskhash proporciona una tabla de hash cerrada almacenada en un único
+// archivo, para diccionarios que no entran en memoria y deben sobrevivir a
+// reinicios del proceso.
+//
+// La tabla resuelve colisiones con prueba lineal, igual que
+// hashtable.HashTable, pero las operaciones se realizan directamente sobre el
+// archivo: en memoria sólo se mantiene el encabezado. El formato del archivo
+// se describe en format.go.
+package diskhash
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// ErrClosed se devuelve al operar sobre una tabla cerrada.
+var ErrClosed = errors.New("diskhash: tabla cerrada")
+
+// HashTable es una tabla de hash cerrada almacenada en un archivo, con claves
+// string y valores []byte.
+type HashTable struct {
+	// path es la ruta del archivo.
+	path string
+	// file es el archivo abierto, o nil si la tabla está cerrada.
+	file *os.File
+	// header es la copia en memoria del encabezado vigente.
+	header header
+	// headerSlot es la copia del encabezado (0 o 1) escrita por última vez.
+	headerSlot int
+}
+
+// Create crea un nuevo archivo con una tabla vacía con la capacidad y el
+// factor de carga especificados.
+//
+// - Si la capacidad es igual a 0, se establece en 16.
+//
+// - Si el factor de carga es menor o igual a 0 o mayor que 1, se establece en
+// 0.75.
+//
+// - La capacidad se redondea a la siguiente potencia de dos.
+//
+// Devuelve un error si el archivo ya existe.
+func Create(path string, capacity uint, loadFactor float32) (*HashTable, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	ht, err := initialize(path, file, capacity, loadFactor)
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return ht, nil
+}
+
+// Open abre un archivo creado con Create.
+//
+// - Si el encabezado no es consistente con el archivo, por ejemplo porque su
+// índice no entra en el archivo o su factor de carga no está en (0, 1],
+// devuelve un error que envuelve ErrCorrupted.
+func Open(path string) (*HashTable, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	h, slot, err := readHeader(file)
+	if err == nil {
+		err = checkSize(file, &h)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	ht := &HashTable{path: path, file: file, header: h, headerSlot: slot}
+	if err := ht.recount(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return ht, nil
+}
+
+// Close cierra el archivo de la tabla. Luego de cerrada, todas las operaciones
+// devuelven ErrClosed.
+func (ht *HashTable) Close() error {
+	if ht.file == nil {
+		return ErrClosed
+	}
+	err := errors.Join(ht.file.Sync(), ht.file.Close())
+	ht.file = nil
+	return err
+}
+
+// Put agrega un nuevo par clave-valor a la tabla. Si la clave ya existe,
+// actualiza el valor asociado a la clave.
+//
+// - Si la tabla está llena, se redimensiona automáticamente reescribiendo el
+// archivo.
+//
+// - Al actualizar un valor, el registro anterior queda sin uso en el archivo
+// hasta el próximo redimensionamiento.
+func (ht *HashTable) Put(key string, value []byte) error {
+	if ht.file == nil {
+		return ErrClosed
+	}
+	if ht.header.used >= ht.threshold() {
+		if err := ht.resize(ht.header.capacity * 2); err != nil {
+			return err
+		}
+	}
+
+	index, found, err := ht.find(key, true)
+	if err != nil {
+		return err
+	}
+	slot, err := ht.readSlot(index)
+	if err != nil {
+		return err
+	}
+
+	// El registro se agrega al final de los datos y se confirma con el
+	// encabezado antes de actualizar el índice, de modo que el índice nunca
+	// apunte a un registro que no llegó a persistirse.
+	offset := ht.header.dataEnd
+	record := encodeRecord(key, value)
+	if _, err := ht.file.WriteAt(record, int64(offset)); err != nil {
+		return err
+	}
+	h := ht.header
+	h.dataEnd += uint64(len(record))
+	if !found {
+		h.size++
+		if slot == emptySlot {
+			h.used++
+		}
+	}
+	if err := ht.writeHeader(h); err != nil {
+		return err
+	}
+	return ht.writeSlot(index, offset)
+}
+
+// Get devuelve el valor asociado a la clave dada y true para indicar que
+// encontró la clave buscada.
+//
+// - Si la clave no existe, devuelve false y un valor nulo.
+func (ht *HashTable) Get(key string) ([]byte, bool, error) {
+	if ht.file == nil {
+		return nil, false, ErrClosed
+	}
+	index, found, err := ht.find(key, false)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	offset, err := ht.readSlot(index)
+	if err != nil {
+		return nil, false, err
+	}
+	_, value, err := readRecord(ht.file, offset, ht.header.dataEnd)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Remove elimina el par clave-valor asociado a la clave dada.
+//
+// Devuelve true si se eliminó el elemento, false si la clave no existe.
+func (ht *HashTable) Remove(key string) (bool, error) {
+	if ht.file == nil {
+		return false, ErrClosed
+	}
+	index, found, err := ht.find(key, false)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := ht.writeSlot(index, deletedSlot); err != nil {
+		return false, err
+	}
+	h := ht.header
+	h.size--
+	return true, ht.writeHeader(h)
+}
+
+// Keys devuelve una lista de todas las claves de la tabla.
+func (ht *HashTable) Keys() ([]string, error) {
+	if ht.file == nil {
+		return nil, ErrClosed
+	}
+	keys := make([]string, 0, ht.header.size)
+	err := ht.each(func(key string, _ []byte) {
+		keys = append(keys, key)
+	})
+	return keys, err
+}
+
+// Size devuelve el número de elementos en la tabla.
+func (ht *HashTable) Size() uint {
+	return uint(ht.header.size)
+}
+
+// IsEmpty devuelve true si la tabla está vacía, false en caso contrario.
+func (ht *HashTable) IsEmpty() bool {
+	return ht.header.size == 0
+}
+
+// Capacity devuelve la cantidad de buckets de la tabla.
+func (ht *HashTable) Capacity() uint {
+	return uint(ht.header.capacity)
+}
+
+// Compact reescribe el archivo descartando los registros sin uso y los
+// buckets eliminados, sin cambiar la capacidad.
+func (ht *HashTable) Compact() error {
+	if ht.file == nil {
+		return ErrClosed
+	}
+	return ht.resize(ht.header.capacity)
+}
+
+// String devuelve una representación en cadena de la tabla.
+func (ht *HashTable) String() string {
+	result := "{"
+	ht.each(func(key string, value []byte) {
+		result += fmt.Sprintf("%v: %q", key, value) + ", "
+	})
+	if len(result) > 1 {
+		result = result[:len(result)-2]
+	}
+	result += "}"
+	return result
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// initialize escribe una tabla vacía en el archivo dado.
+func initialize(path string, file *os.File, capacity uint, loadFactor float32) (*HashTable, error) {
+	if capacity == 0 {
+		capacity = 16
+	}
+	if loadFactor <= 0 || loadFactor > 1 {
+		loadFactor = 0.75
+	}
+	h := header{
+		capacity:   hashing.NextPowerOfTwo(uint64(capacity)),
+		loadFactor: loadFactor,
+	}
+	h.dataEnd = h.dataOffset()
+	if err := file.Truncate(int64(h.dataEnd)); err != nil {
+		return nil, err
+	}
+	// Escribimos ambas copias del encabezado para que las dos sean válidas.
+	ht := &HashTable{path: path, file: file, headerSlot: 1}
+	if err := ht.writeHeader(h); err != nil {
+		return nil, err
+	}
+	if err := ht.writeHeader(h); err != nil {
+		return nil, err
+	}
+	return ht, nil
+}
+
+// recount recalcula la cantidad de claves y de buckets usados a partir del
+// índice. Si la última operación antes de una falla llegó a escribir el
+// encabezado pero no el índice (o viceversa), los contadores del encabezado
+// pueden diferir en uno; en ese caso se corrigen.
+//
+// Los buckets que apuntan fuera de la región de datos vigente se marcan como
+// eliminados.
+//
+// El índice se recorre de a bloques, por lo que no necesita entrar en memoria.
+func (ht *HashTable) recount() error {
+	var size, used uint64
+	err := scanIndex(ht.file, ht.header.capacity, func(i, offset uint64) error {
+		switch {
+		case offset == emptySlot:
+		case offset == deletedSlot:
+			used++
+		case offset < ht.header.dataOffset() || offset >= ht.header.dataEnd:
+			used++
+			return ht.writeSlot(i, deletedSlot)
+		default:
+			size++
+			used++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if size == ht.header.size && used == ht.header.used {
+		return nil
+	}
+	h := ht.header
+	h.size, h.used = size, used
+	return ht.writeHeader(h)
+}
+
+// checkSize verifica que el encabezado sea consistente con el tamaño del
+// archivo.
+func checkSize(file *os.File, h *header) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return h.check(info.Size())
+}
+
+// threshold devuelve la cantidad de buckets usados a partir de la cual se
+// redimensiona la tabla.
+func (ht *HashTable) threshold() uint64 {
+	return uint64(float32(ht.header.capacity) * ht.header.loadFactor)
+}
+
+// hash calcula el bucket inicial de una clave.
+func (ht *HashTable) hash(key string) uint64 {
+	return hashing.String64(key, 0) & (ht.header.capacity - 1)
+}
+
+// find busca la clave con prueba lineal. Devuelve el bucket que la contiene y
+// true, o false si no existe. Si forInsert es true y la clave no existe,
+// devuelve el primer bucket vacío o eliminado donde puede insertarse.
+func (ht *HashTable) find(key string, forInsert bool) (uint64, bool, error) {
+	free, hasFree := uint64(0), false
+	index := ht.hash(key)
+	for range ht.header.capacity {
+		offset, err := ht.readSlot(index)
+		if err != nil {
+			return 0, false, err
+		}
+		switch offset {
+		case emptySlot:
+			if !hasFree {
+				free = index
+			}
+			return free, false, nil
+		case deletedSlot:
+			if !hasFree {
+				free, hasFree = index, true
+			}
+		default:
+			stored, _, err := readRecord(ht.file, offset, ht.header.dataEnd)
+			if err != nil {
+				return 0, false, err
+			}
+			if stored == key {
+				return index, true, nil
+			}
+		}
+		index = (index + 1) & (ht.header.capacity - 1)
+	}
+	if forInsert && !hasFree {
+		return 0, false, fmt.Errorf("%w: no hay buckets libres", ErrCorrupted)
+	}
+	return free, false, nil
+}
+
+// readSlot lee el contenido de un bucket del índice.
+func (ht *HashTable) readSlot(index uint64) (uint64, error) {
+	buf := make([]byte, slotSize)
+	if _, err := ht.file.ReadAt(buf, int64(indexOffset+slotSize*index)); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// writeSlot escribe el contenido de un bucket del índice.
+func (ht *HashTable) writeSlot(index, offset uint64) error {
+	buf := binary.LittleEndian.AppendUint64(nil, offset)
+	_, err := ht.file.WriteAt(buf, int64(indexOffset+slotSize*index))
+	return err
+}
+
+// writeHeader escribe el encabezado en la copia que no está vigente.
+//
+// Antes de escribirlo se sincronizan los datos con el disco, de modo que el
+// encabezado nunca haga referencia a datos que no llegaron a persistirse.
+// Luego se sincroniza el propio encabezado.
+func (ht *HashTable) writeHeader(h header) error {
+	if err := ht.file.Sync(); err != nil {
+		return err
+	}
+	h.sequence = ht.header.sequence + 1
+	slot := 1 - ht.headerSlot
+	if _, err := ht.file.WriteAt(h.encode(), int64(slot*headerSlotSize)); err != nil {
+		return err
+	}
+	if err := ht.file.Sync(); err != nil {
+		return err
+	}
+	ht.header = h
+	ht.headerSlot = slot
+	return nil
+}
+
+// each recorre todos los pares clave-valor de la tabla.
+func (ht *HashTable) each(fn func(key string, value []byte)) error {
+	if ht.file == nil {
+		return ErrClosed
+	}
+	for index := range ht.header.capacity {
+		offset, err := ht.readSlot(index)
+		if err != nil {
+			return err
+		}
+		if offset == emptySlot || offset == deletedSlot {
+			continue
+		}
+		key, value, err := readRecord(ht.file, offset, ht.header.dataEnd)
+		if err != nil {
+			return err
+		}
+		fn(key, value)
+	}
+	return nil
+}
+
+// resize reescribe la tabla en un archivo temporal con la nueva capacidad y lo
+// renombra sobre el archivo original. Como el renombrado es atómico, ante una
+// falla el archivo original queda intacto. Luego se sincroniza el directorio,
+// para que el renombrado también sobreviva a una falla.
+//
+// - Si falla la sincronización del directorio, el renombrado ya se realizó:
+// la tabla pasa a usar el nuevo archivo y se devuelve el error.
+func (ht *HashTable) resize(capacity uint64) error {
+	tmpPath := ht.path + ".tmp"
+	os.Remove(tmpPath)
+	tmp, err := Create(tmpPath, uint(capacity), ht.header.loadFactor)
+	if err != nil {
+		return err
+	}
+	err = ht.each(func(key string, value []byte) {
+		if err == nil {
+			err = tmp.insertNew(key, value)
+		}
+	})
+	if err == nil {
+		err = tmp.writeHeader(tmp.header)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, ht.path); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	err = syncDir(filepath.Dir(ht.path))
+	ht.file.Close()
+	ht.file, ht.header, ht.headerSlot = tmp.file, tmp.header, tmp.headerSlot
+	return err
+}
+
+// syncDir sincroniza el directorio dado, para que los renombrados dentro de
+// él lleguen al disco.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return errors.Join(dir.Sync(), dir.Close())
+}
+
+// insertNew agrega una clave que se sabe que no existe, sin redimensionar ni
+// escribir el encabezado. Se utiliza al reescribir la tabla.
+func (ht *HashTable) insertNew(key string, value []byte) error {
+	index := ht.hash(key)
+	for {
+		offset, err := ht.readSlot(index)
+		if err != nil {
+			return err
+		}
+		if offset == emptySlot {
+			break
+		}
+		index = (index + 1) & (ht.header.capacity - 1)
+	}
+	record := encodeRecord(key, value)
+	if _, err := ht.file.WriteAt(record, int64(ht.header.dataEnd)); err != nil {
+		return err
+	}
+	if err := ht.writeSlot(index, ht.header.dataEnd); err != nil {
+		return err
+	}
+	ht.header.dataEnd += uint64(len(record))
+	ht.header.size++
+	ht.header.used++
+	return nil
+}
@@ -0,0 +1,251 @@
+package diskhash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTable crea una tabla en un directorio temporal del test.
+func newTable(t *testing.T, capacity uint) (*HashTable, string) {
+	path := filepath.Join(t.TempDir(), "tabla.dht")
+	ht, err := Create(path, capacity, 0.75)
+	require.NoError(t, err)
+	t.Cleanup(func() { ht.Close() })
+	return ht, path
+}
+
+func TestCreate(t *testing.T) {
+	ht, path := newTable(t, 10)
+
+	assert.True(t, ht.IsEmpty())
+	assert.Equal(t, uint(16), ht.Capacity())
+	assert.NoError(t, Verify(path))
+
+	_, err := Create(path, 10, 0.75)
+	assert.ErrorIs(t, err, os.ErrExist)
+}
+
+func TestPutGet(t *testing.T) {
+	ht, _ := newTable(t, 0)
+
+	require.NoError(t, ht.Put("Dungeons", []byte("Calabozos")))
+	require.NoError(t, ht.Put("Dragons", []byte("Dragones")))
+	require.NoError(t, ht.Put("", []byte("vacía")))
+
+	v, ok, err := ht.Get("Dungeons")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("Calabozos"), v)
+
+	v, ok, err = ht.Get("")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("vacía"), v)
+
+	_, ok, err = ht.Get("Dwarf")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, uint(3), ht.Size())
+}
+
+func TestPutActualiza(t *testing.T) {
+	ht, path := newTable(t, 0)
+
+	require.NoError(t, ht.Put("a", []byte("1")))
+	require.NoError(t, ht.Put("a", []byte("2")))
+
+	v, _, _ := ht.Get("a")
+	assert.Equal(t, []byte("2"), v)
+	assert.Equal(t, uint(1), ht.Size())
+	assert.NoError(t, Verify(path))
+}
+
+func TestRemove(t *testing.T) {
+	ht, path := newTable(t, 0)
+	require.NoError(t, ht.Put("a", []byte("1")))
+	require.NoError(t, ht.Put("b", []byte("2")))
+
+	ok, err := ht.Remove("a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = ht.Remove("a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, _ = ht.Get("a")
+	assert.False(t, ok)
+	v, ok, _ := ht.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), v)
+	assert.Equal(t, uint(1), ht.Size())
+	assert.NoError(t, Verify(path))
+}
+
+func TestResize(t *testing.T) {
+	ht, path := newTable(t, 4)
+
+	for i := range 500 {
+		require.NoError(t, ht.Put(fmt.Sprintf("clave-%d", i), fmt.Appendf(nil, "valor-%d", i)))
+	}
+	for i := range 250 {
+		_, err := ht.Remove(fmt.Sprintf("clave-%d", i))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, uint(250), ht.Size())
+	assert.GreaterOrEqual(t, ht.Capacity(), uint(500))
+	for i := range 500 {
+		v, ok, err := ht.Get(fmt.Sprintf("clave-%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, i >= 250, ok)
+		if ok {
+			assert.Equal(t, fmt.Appendf(nil, "valor-%d", i), v)
+		}
+	}
+	keys, err := ht.Keys()
+	require.NoError(t, err)
+	assert.Len(t, keys, 250)
+	assert.NoError(t, Verify(path))
+	_, err = os.Stat(path + ".tmp")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestCompact(t *testing.T) {
+	ht, path := newTable(t, 64)
+	for i := range 20 {
+		require.NoError(t, ht.Put("a", fmt.Appendf(nil, "valor-%d", i)))
+	}
+	before, _ := os.Stat(path)
+
+	require.NoError(t, ht.Compact())
+	after, _ := os.Stat(path)
+	assert.Less(t, after.Size(), before.Size())
+	v, _, _ := ht.Get("a")
+	assert.Equal(t, []byte("valor-19"), v)
+	assert.NoError(t, Verify(path))
+}
+
+func TestOpenPersiste(t *testing.T) {
+	ht, path := newTable(t, 0)
+	for i := range 100 {
+		require.NoError(t, ht.Put(fmt.Sprintf("clave-%d", i), []byte{byte(i)}))
+	}
+	require.NoError(t, ht.Close())
+
+	ht, err := Open(path)
+	require.NoError(t, err)
+	defer ht.Close()
+	assert.Equal(t, uint(100), ht.Size())
+	for i := range 100 {
+		v, ok, err := ht.Get(fmt.Sprintf("clave-%d", i))
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte{byte(i)}, v)
+	}
+}
+
+func TestOpenEscrituraInterrumpida(t *testing.T) {
+	ht, path := newTable(t, 0)
+	require.NoError(t, ht.Put("a", []byte("1")))
+	h, slot := ht.header, ht.headerSlot
+	require.NoError(t, ht.Close())
+
+	// Simulamos una falla durante Put("b"): el registro llegó a escribirse,
+	// pero la escritura del encabezado quedó a medias y el índice no se
+	// actualizó. Se utiliza la copia anterior del encabezado.
+	corrupt(t, path, int64(h.dataEnd), encodeRecord("b", []byte("2")))
+	corrupt(t, path, int64((1-slot)*headerSlotSize), []byte("DHTB basura"))
+	assert.NoError(t, Verify(path))
+
+	ht, err := Open(path)
+	require.NoError(t, err)
+	defer ht.Close()
+	assert.Equal(t, uint(1), ht.Size())
+	_, ok, err := ht.Get("a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, ok, err = ht.Get("b")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, ht.Put("b", []byte("3")))
+	v, _, _ := ht.Get("b")
+	assert.Equal(t, []byte("3"), v)
+}
+
+func TestOpenBucketFueraDeDatos(t *testing.T) {
+	ht, path := newTable(t, 0)
+	require.NoError(t, ht.Put("a", []byte("1")))
+	index, _, err := ht.find("a", false)
+	require.NoError(t, err)
+	require.NoError(t, ht.writeSlot(index, ht.header.dataEnd+10))
+	require.NoError(t, ht.Close())
+	assert.ErrorIs(t, Verify(path), ErrCorrupted)
+
+	// Al abrir la tabla, el bucket inválido se marca como eliminado.
+	ht, err = Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), ht.Size())
+	_, ok, err := ht.Get("a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, ht.Close())
+	assert.NoError(t, Verify(path))
+}
+
+func TestOpenInvalido(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalido")
+	require.NoError(t, os.WriteFile(path, make([]byte, 200), 0o644))
+
+	_, err := Open(path)
+	assert.ErrorIs(t, err, ErrCorrupted)
+}
+
+func TestOpenEncabezadoInconsistente(t *testing.T) {
+	tests := map[string]func(h *header){
+		"capacidad enorme":  func(h *header) { h.capacity = 1 << 60 },
+		"capacidad máxima":  func(h *header) { h.capacity = 1 << 63 },
+		"factor de carga 0": func(h *header) { h.loadFactor = 0 },
+		"factor de carga 2": func(h *header) { h.loadFactor = 2 },
+		"fin de datos":      func(h *header) { h.dataEnd = 1 << 40 },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			ht, path := newTable(t, 0)
+			require.NoError(t, ht.Put("a", []byte("1")))
+			h := ht.header
+			modify(&h)
+			require.NoError(t, ht.writeHeader(h))
+			require.NoError(t, ht.Close())
+
+			_, err := Open(path)
+			assert.ErrorIs(t, err, ErrCorrupted)
+			assert.ErrorIs(t, Verify(path), ErrCorrupted)
+		})
+	}
+}
+
+func TestClosed(t *testing.T) {
+	ht, _ := newTable(t, 0)
+	require.NoError(t, ht.Close())
+
+	assert.ErrorIs(t, ht.Put("a", nil), ErrClosed)
+	_, _, err := ht.Get("a")
+	assert.ErrorIs(t, err, ErrClosed)
+	_, err = ht.Remove("a")
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.ErrorIs(t, ht.Close(), ErrClosed)
+}
+
+func TestString(t *testing.T) {
+	ht, _ := newTable(t, 0)
+	assert.Equal(t, "{}", ht.String())
+
+	require.NoError(t, ht.Put("a", []byte("1")))
+	assert.Equal(t, `{a: "1"}`, ht.String())
+}
@@ -0,0 +1,218 @@
+package diskhash
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// Formato del archivo ////////////////////////////////////////////////////////
+//
+// El archivo se divide en tres regiones:
+//
+//	[0, 128)                encabezado, en dos copias de 64 bytes
+//	[128, 128 + 8*capacity) índice de buckets: un desplazamiento por bucket
+//	[128 + 8*capacity, ...) registros clave-valor de longitud variable
+//
+// El encabezado se escribe alternando entre sus dos copias, cada una con un
+// número de secuencia y un checksum. Al abrir el archivo se utiliza la copia
+// válida con mayor secuencia, de modo que una escritura interrumpida del
+// encabezado nunca deja el archivo sin un encabezado consistente.
+//
+// Cada bucket del índice contiene el desplazamiento del registro que ocupa el
+// bucket, emptySlot si está vacío o deletedSlot si el registro fue eliminado.
+//
+// Cada registro tiene la forma: longitud de la clave (4 bytes), longitud del
+// valor (4 bytes), clave, valor y checksum CRC-32 de todo lo anterior (4
+// bytes). Todos los enteros se codifican en little endian.
+
+// magic identifica el formato del archivo.
+var magic = [4]byte{'D', 'H', 'T', 'B'}
+
+// version es la versión del formato del archivo.
+const version = 1
+
+const (
+	// headerSlotSize es el tamaño reservado para cada copia del encabezado.
+	headerSlotSize = 64
+	// headerSize es la cantidad de bytes utilizados de cada copia.
+	headerSize = 56
+	// indexOffset es la posición del índice de buckets en el archivo.
+	indexOffset = 2 * headerSlotSize
+	// slotSize es el tamaño de cada bucket del índice.
+	slotSize = 8
+	// recordHeaderSize es el tamaño de las longitudes al inicio de un registro.
+	recordHeaderSize = 8
+	// recordTrailerSize es el tamaño del checksum al final de un registro.
+	recordTrailerSize = 4
+	// indexChunkSlots es la cantidad de buckets que se leen por vez al
+	// recorrer todo el índice, para no cargarlo completo en memoria.
+	indexChunkSlots = 4096
+)
+
+// Valores especiales de un bucket del índice.
+const (
+	emptySlot   uint64 = 0
+	deletedSlot uint64 = math.MaxUint64
+)
+
+// ErrCorrupted se devuelve cuando el contenido del archivo no es consistente.
+var ErrCorrupted = errors.New("diskhash: archivo corrupto")
+
+// header es el encabezado del archivo.
+type header struct {
+	// sequence se incrementa en cada escritura del encabezado.
+	sequence uint64
+	// capacity es la cantidad de buckets del índice.
+	capacity uint64
+	// size es la cantidad de claves almacenadas.
+	size uint64
+	// used es la cantidad de buckets ocupados o eliminados.
+	used uint64
+	// dataEnd es la posición del final de la región de registros.
+	dataEnd uint64
+	// loadFactor es el factor de carga de la tabla.
+	loadFactor float32
+}
+
+// dataOffset devuelve la posición de la región de registros.
+func (h *header) dataOffset() uint64 {
+	return indexOffset + slotSize*h.capacity
+}
+
+// check verifica que los valores del encabezado sean consistentes con un
+// archivo de size bytes: que la capacidad sea una potencia de dos, que el
+// índice y la región de datos entren en el archivo y que el factor de carga
+// esté en (0, 1].
+func (h *header) check(size int64) error {
+	if h.capacity == 0 || h.capacity&(h.capacity-1) != 0 {
+		return fmt.Errorf("%w: la capacidad %d no es potencia de dos", ErrCorrupted, h.capacity)
+	}
+	// Se divide en lugar de calcular dataOffset, que puede desbordar.
+	if size < indexOffset || h.capacity > uint64(size-indexOffset)/slotSize {
+		return fmt.Errorf("%w: el índice de %d buckets no entra en el archivo de %d bytes", ErrCorrupted, h.capacity, size)
+	}
+	if h.dataEnd < h.dataOffset() || h.dataEnd > uint64(size) {
+		return fmt.Errorf("%w: fin de datos %d fuera del archivo de %d bytes", ErrCorrupted, h.dataEnd, size)
+	}
+	if !(h.loadFactor > 0 && h.loadFactor <= 1) {
+		return fmt.Errorf("%w: factor de carga inválido %v", ErrCorrupted, h.loadFactor)
+	}
+	return nil
+}
+
+// encode serializa el encabezado, incluyendo su checksum.
+func (h *header) encode() []byte {
+	buf := make([]byte, 0, headerSlotSize)
+	buf = append(buf, magic[:]...)
+	buf = binary.LittleEndian.AppendUint32(buf, version)
+	buf = binary.LittleEndian.AppendUint64(buf, h.sequence)
+	buf = binary.LittleEndian.AppendUint64(buf, h.capacity)
+	buf = binary.LittleEndian.AppendUint64(buf, h.size)
+	buf = binary.LittleEndian.AppendUint64(buf, h.used)
+	buf = binary.LittleEndian.AppendUint64(buf, h.dataEnd)
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(h.loadFactor))
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+	return buf
+}
+
+// decodeHeader deserializa un encabezado y verifica su checksum.
+func decodeHeader(buf []byte) (header, error) {
+	if len(buf) < headerSize || [4]byte(buf[:4]) != magic {
+		return header{}, fmt.Errorf("%w: encabezado inválido", ErrCorrupted)
+	}
+	if crc32.ChecksumIEEE(buf[:headerSize-4]) != binary.LittleEndian.Uint32(buf[headerSize-4:]) {
+		return header{}, fmt.Errorf("%w: checksum del encabezado inválido", ErrCorrupted)
+	}
+	if v := binary.LittleEndian.Uint32(buf[4:]); v != version {
+		return header{}, fmt.Errorf("%w: versión %d no soportada", ErrCorrupted, v)
+	}
+	return header{
+		sequence:   binary.LittleEndian.Uint64(buf[8:]),
+		capacity:   binary.LittleEndian.Uint64(buf[16:]),
+		size:       binary.LittleEndian.Uint64(buf[24:]),
+		used:       binary.LittleEndian.Uint64(buf[32:]),
+		dataEnd:    binary.LittleEndian.Uint64(buf[40:]),
+		loadFactor: math.Float32frombits(binary.LittleEndian.Uint32(buf[48:])),
+	}, nil
+}
+
+// readHeader lee ambas copias del encabezado y devuelve la válida con mayor
+// secuencia, junto con la posición de la copia elegida (0 o 1).
+func readHeader(r io.ReaderAt) (header, int, error) {
+	buf := make([]byte, indexOffset)
+	if _, err := r.ReadAt(buf, 0); err != nil {
+		return header{}, 0, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+	h0, err0 := decodeHeader(buf[:headerSlotSize])
+	h1, err1 := decodeHeader(buf[headerSlotSize:])
+	switch {
+	case err0 != nil && err1 != nil:
+		return header{}, 0, err0
+	case err1 != nil || (err0 == nil && h0.sequence >= h1.sequence):
+		return h0, 0, nil
+	default:
+		return h1, 1, nil
+	}
+}
+
+// scanIndex recorre los capacity buckets del índice, de a indexChunkSlots por
+// vez, llamando a fn con la posición y el contenido de cada uno. Se detiene en
+// el primer error que devuelva fn.
+func scanIndex(r io.ReaderAt, capacity uint64, fn func(index, offset uint64) error) error {
+	buf := make([]byte, slotSize*min(capacity, indexChunkSlots))
+	for start := uint64(0); start < capacity; start += indexChunkSlots {
+		chunk := buf[:slotSize*min(capacity-start, indexChunkSlots)]
+		if _, err := r.ReadAt(chunk, int64(indexOffset+slotSize*start)); err != nil {
+			return fmt.Errorf("%w: %w", ErrCorrupted, err)
+		}
+		for i := range uint64(len(chunk) / slotSize) {
+			if err := fn(start+i, binary.LittleEndian.Uint64(chunk[slotSize*i:])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// encodeRecord serializa un par clave-valor como registro.
+func encodeRecord(key string, value []byte) []byte {
+	buf := make([]byte, 0, recordHeaderSize+len(key)+len(value)+recordTrailerSize)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(key)))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(value)))
+	buf = append(buf, key...)
+	buf = append(buf, value...)
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+}
+
+// readRecord lee y verifica el registro ubicado en offset, que debe terminar
+// antes de end.
+func readRecord(r io.ReaderAt, offset, end uint64) (string, []byte, error) {
+	if offset+recordHeaderSize > end {
+		return "", nil, fmt.Errorf("%w: registro fuera de rango en %d", ErrCorrupted, offset)
+	}
+	lengths := make([]byte, recordHeaderSize)
+	if _, err := r.ReadAt(lengths, int64(offset)); err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+	keyLen := uint64(binary.LittleEndian.Uint32(lengths))
+	valueLen := uint64(binary.LittleEndian.Uint32(lengths[4:]))
+	total := recordHeaderSize + keyLen + valueLen + recordTrailerSize
+	if offset+total > end {
+		return "", nil, fmt.Errorf("%w: registro fuera de rango en %d", ErrCorrupted, offset)
+	}
+	buf := make([]byte, total)
+	if _, err := r.ReadAt(buf, int64(offset)); err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+	body := buf[:total-recordTrailerSize]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(buf[total-recordTrailerSize:]) {
+		return "", nil, fmt.Errorf("%w: checksum inválido del registro en %d", ErrCorrupted, offset)
+	}
+	key := string(body[recordHeaderSize : recordHeaderSize+keyLen])
+	value := body[recordHeaderSize+keyLen:]
+	return key, value, nil
+}
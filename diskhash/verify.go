@@ -0,0 +1,135 @@
+package diskhash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// Verify comprueba, sin modificarlo, la integridad estructural de un archivo
+// creado con Create. El archivo no debe estar siendo modificado mientras se
+// verifica.
+//
+// Se comprueba que:
+//
+// - Al menos una copia del encabezado sea válida y sus valores consistentes.
+//
+// - Cada bucket ocupado apunte a un registro dentro de la región de datos y
+// con checksum válido.
+//
+// - Cada clave sea alcanzable por prueba lineal desde su bucket inicial y no
+// esté repetida.
+//
+// - Los contadores del encabezado coincidan con el contenido del índice.
+//
+// Devuelve nil si el archivo es válido, o un error que agrupa todos los
+// problemas encontrados, cada uno compatible con errors.Is(err, ErrCorrupted).
+func Verify(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	h, _, err := readHeader(file)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	var problems []error
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf("%w: "+format, append([]any{ErrCorrupted}, args...)...))
+	}
+
+	if h.capacity == 0 || h.capacity&(h.capacity-1) != 0 {
+		report("la capacidad %d no es potencia de dos", h.capacity)
+		return errors.Join(problems...)
+	}
+	if info.Size() < indexOffset || h.capacity > uint64(info.Size()-indexOffset)/slotSize {
+		report("el índice de %d buckets no entra en el archivo de %d bytes", h.capacity, info.Size())
+		return errors.Join(problems...)
+	}
+	if !(h.loadFactor > 0 && h.loadFactor <= 1) {
+		report("factor de carga inválido %v", h.loadFactor)
+	}
+	if h.dataEnd < h.dataOffset() || h.dataEnd > uint64(info.Size()) {
+		report("fin de datos %d fuera del archivo de %d bytes", h.dataEnd, info.Size())
+		return errors.Join(problems...)
+	}
+
+	// El índice se recorre de a bloques. Para saber si una clave es alcanzable
+	// alcanza con recordar el último bucket vacío: entre el bucket inicial y
+	// el actual no puede haber buckets vacíos. Las claves cuyo recorrido da la
+	// vuelta al índice se comprueban al final, cuando se conocen sus últimos
+	// buckets.
+	type wrapped struct {
+		key   string
+		index uint64
+		home  uint64
+	}
+	var size, used, lastEmpty uint64
+	var hasEmpty bool
+	var pending []wrapped
+	unreachable := func(key string, i uint64) {
+		report("la clave %q del bucket %d no es alcanzable desde su bucket inicial", key, i)
+	}
+	seen := make(map[string]uint64)
+	err = scanIndex(file, h.capacity, func(i, offset uint64) error {
+		if offset == emptySlot {
+			hasEmpty, lastEmpty = true, i
+			return nil
+		}
+		used++
+		if offset == deletedSlot {
+			return nil
+		}
+		size++
+		if offset < h.dataOffset() {
+			report("el bucket %d apunta dentro del índice (%d)", i, offset)
+			return nil
+		}
+		key, _, err := readRecord(file, offset, h.dataEnd)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("bucket %d: %w", i, err))
+			return nil
+		}
+		if j, ok := seen[key]; ok {
+			report("la clave %q está repetida en los buckets %d y %d", key, j, i)
+		}
+		seen[key] = i
+		switch home := hashing.String64(key, 0) & (h.capacity - 1); {
+		case home <= i:
+			if hasEmpty && lastEmpty >= home {
+				unreachable(key, i)
+			}
+		case hasEmpty:
+			unreachable(key, i)
+		default:
+			pending = append(pending, wrapped{key, i, home})
+		}
+		return nil
+	})
+	if err != nil {
+		problems = append(problems, err)
+		return errors.Join(problems...)
+	}
+	for _, w := range pending {
+		if hasEmpty && lastEmpty >= w.home {
+			unreachable(w.key, w.index)
+		}
+	}
+
+	if size != h.size {
+		report("el encabezado indica %d claves pero el índice tiene %d", h.size, size)
+	}
+	if used != h.used {
+		report("el encabezado indica %d buckets usados pero el índice tiene %d", h.used, used)
+	}
+	return errors.Join(problems...)
+}
@@ -0,0 +1,134 @@
+package diskhash
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// corrupt escribe data en la posición offset del archivo.
+func corrupt(t *testing.T, path string, offset int64, data []byte) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	require.NoError(t, err)
+	_, err = file.WriteAt(data, offset)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
+
+// filledTable crea una tabla con n claves y la cierra.
+func filledTable(t *testing.T, n int) (string, header) {
+	ht, path := newTable(t, 0)
+	for i := range n {
+		require.NoError(t, ht.Put(fmt.Sprintf("clave-%d", i), []byte("valor")))
+	}
+	h := ht.header
+	require.NoError(t, ht.Close())
+	return path, h
+}
+
+func TestVerifyValido(t *testing.T) {
+	path, _ := filledTable(t, 50)
+
+	assert.NoError(t, Verify(path))
+}
+
+func TestVerifyRegistroDanado(t *testing.T) {
+	path, h := filledTable(t, 10)
+	corrupt(t, path, int64(h.dataOffset()+recordHeaderSize), []byte("X"))
+
+	assert.ErrorIs(t, Verify(path), ErrCorrupted)
+}
+
+func TestVerifyBucketFueraDeRango(t *testing.T) {
+	path, h := filledTable(t, 10)
+	for i := range h.capacity {
+		offset := int64(indexOffset + slotSize*i)
+		buf := make([]byte, slotSize)
+		file, _ := os.Open(path)
+		file.ReadAt(buf, offset)
+		file.Close()
+		if binary.LittleEndian.Uint64(buf) != emptySlot {
+			corrupt(t, path, offset, binary.LittleEndian.AppendUint64(nil, h.dataEnd+100))
+			break
+		}
+	}
+
+	assert.ErrorIs(t, Verify(path), ErrCorrupted)
+}
+
+func TestVerifyClaveInalcanzable(t *testing.T) {
+	path, h := filledTable(t, 10)
+	// Movemos un bucket ocupado a la posición anterior a su bucket inicial, de
+	// modo que quede separado por buckets vacíos.
+	ht, err := Open(path)
+	require.NoError(t, err)
+	home := ht.hash("clave-0")
+	index, _, err := ht.find("clave-0", false)
+	require.NoError(t, err)
+	offset, err := ht.readSlot(index)
+	require.NoError(t, err)
+	require.NoError(t, ht.writeSlot(index, emptySlot))
+	require.NoError(t, ht.writeSlot((home+h.capacity-1)&(h.capacity-1), offset))
+	require.NoError(t, ht.file.Close())
+
+	err = Verify(path)
+	assert.ErrorIs(t, err, ErrCorrupted)
+	assert.Contains(t, err.Error(), "no es alcanzable")
+}
+
+func TestVerifyContadores(t *testing.T) {
+	path, _ := filledTable(t, 10)
+	ht, err := Open(path)
+	require.NoError(t, err)
+	h := ht.header
+	h.size = 42
+	require.NoError(t, ht.writeHeader(h))
+	require.NoError(t, ht.Close())
+
+	err = Verify(path)
+	assert.ErrorIs(t, err, ErrCorrupted)
+	assert.Contains(t, err.Error(), "42 claves")
+
+	// Al abrir la tabla se corrigen los contadores.
+	ht, err = Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint(10), ht.Size())
+	require.NoError(t, ht.Close())
+	assert.NoError(t, Verify(path))
+}
+
+func TestVerifyIndiceEnBloques(t *testing.T) {
+	ht, path := newTable(t, 3*indexChunkSlots)
+	for i := range 2 * indexChunkSlots {
+		require.NoError(t, ht.Put(fmt.Sprintf("clave-%d", i), []byte("valor")))
+	}
+	require.Greater(t, ht.Capacity(), uint(indexChunkSlots))
+	require.NoError(t, ht.Close())
+
+	assert.NoError(t, Verify(path))
+}
+
+func TestVerifyCapacidadFueraDelArchivo(t *testing.T) {
+	path, _ := filledTable(t, 10)
+	ht, err := Open(path)
+	require.NoError(t, err)
+	h := ht.header
+	h.capacity = 1 << 60
+	require.NoError(t, ht.writeHeader(h))
+	require.NoError(t, ht.Close())
+
+	err = Verify(path)
+	assert.ErrorIs(t, err, ErrCorrupted)
+	assert.Contains(t, err.Error(), "no entra en el archivo")
+}
+
+func TestVerifyEncabezadosInvalidos(t *testing.T) {
+	path, _ := filledTable(t, 1)
+	corrupt(t, path, 0, make([]byte, indexOffset))
+
+	assert.ErrorIs(t, Verify(path), ErrCorrupted)
+}
@@ -2,7 +2,10 @@ module untref-ayp2/guia-conjuntos-hashes-diccionarios
 
 go 1.24
 
-require github.com/stretchr/testify v1.9.0
+require (
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/text v0.28.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
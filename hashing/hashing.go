@@ -0,0 +1,63 @@
+// hashing proporciona funciones de hash determinísticas compartidas por las
+// estructuras probabilísticas del proyecto (filtros, sketches, anillos, etc.).
+//
+// A diferencia de hash/maphash, los valores calculados no dependen del proceso,
+// por lo que pueden persistirse junto con las estructuras que los usan.
+package hashing
+
+// Constantes de FNV-1a de 64 bits.
+const (
+	offset64 uint64 = 14695981039346656037
+	prime64  uint64 = 1099511628211
+)
+
+// Sum64 calcula un hash de 64 bits de los bytes dados utilizando FNV-1a
+// seguido de una etapa de mezcla final. La semilla permite obtener funciones
+// de hash independientes entre sí a partir de la misma entrada.
+func Sum64(data []byte, seed uint64) uint64 {
+	hash := offset64 ^ Mix64(seed)
+	for _, b := range data {
+		hash ^= uint64(b)
+		hash *= prime64
+	}
+	return Mix64(hash)
+}
+
+// String64 es equivalente a Sum64 pero recibe una cadena, evitando la
+// conversión a []byte.
+func String64(s string, seed uint64) uint64 {
+	hash := offset64 ^ Mix64(seed)
+	for i := 0; i < len(s); i++ {
+		hash ^= uint64(s[i])
+		hash *= prime64
+	}
+	return Mix64(hash)
+}
+
+// Mix64 mezcla los bits de x para que cada bit de entrada afecte a todos los
+// bits de salida. Se utiliza el finalizador de SplitMix64.
+func Mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// NextPowerOfTwo devuelve la menor potencia de dos mayor o igual a n.
+//
+// - Si n es 0, devuelve 1.
+func NextPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+	n--
+	n |= n >> 1
+	n |= n >> 2
+	n |= n >> 4
+	n |= n >> 8
+	n |= n >> 16
+	n |= n >> 32
+	return n + 1
+}
@@ -0,0 +1,40 @@
+package hashing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum64EsDeterministico(t *testing.T) {
+	assert.Equal(t, Sum64([]byte("hola"), 0), Sum64([]byte("hola"), 0))
+	assert.Equal(t, Sum64([]byte("hola"), 7), String64("hola", 7))
+}
+
+func TestSum64SemillasDistintas(t *testing.T) {
+	assert.NotEqual(t, Sum64([]byte("hola"), 1), Sum64([]byte("hola"), 2))
+	assert.NotEqual(t, String64("", 1), String64("", 2))
+}
+
+func TestSum64EntradasDistintas(t *testing.T) {
+	seen := make(map[uint64]bool)
+	for i := 0; i < 10000; i++ {
+		h := String64(string(rune(i))+"clave", 0)
+		assert.False(t, seen[h])
+		seen[h] = true
+	}
+}
+
+func TestMix64(t *testing.T) {
+	assert.Equal(t, uint64(0), Mix64(0))
+	assert.NotEqual(t, Mix64(1), Mix64(2))
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	assert.Equal(t, uint64(1), NextPowerOfTwo(0))
+	assert.Equal(t, uint64(1), NextPowerOfTwo(1))
+	assert.Equal(t, uint64(2), NextPowerOfTwo(2))
+	assert.Equal(t, uint64(4), NextPowerOfTwo(3))
+	assert.Equal(t, uint64(1024), NextPowerOfTwo(1000))
+	assert.Equal(t, uint64(1024), NextPowerOfTwo(1024))
+}
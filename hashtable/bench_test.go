@@ -0,0 +1,63 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchKeys devuelve n claves distintas para los benchmarks.
+func benchKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("clave-%d", i)
+	}
+	return keys
+}
+
+func BenchmarkPut(b *testing.B) {
+	keys := benchKeys(1 << 12)
+	b.ReportAllocs()
+	for b.Loop() {
+		ht := NewHashTable[string, int](uint(2*len(keys)), 0.75)
+		for i, key := range keys {
+			ht.Put(key, i)
+		}
+	}
+}
+
+func BenchmarkPutResize(b *testing.B) {
+	keys := benchKeys(1 << 12)
+	b.ReportAllocs()
+	for b.Loop() {
+		ht := NewHashTable[string, int](0, 0)
+		for i, key := range keys {
+			ht.Put(key, i)
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := benchKeys(1 << 12)
+	ht := NewHashTable[string, int](0, 0)
+	for i, key := range keys {
+		ht.Put(key, i)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, key := range keys {
+			ht.Get(key)
+		}
+	}
+}
+
+func BenchmarkResize(b *testing.B) {
+	keys := benchKeys(1 << 12)
+	ht := NewHashTable[string, int](0, 0)
+	for i, key := range keys {
+		ht.Put(key, i)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		ht.rehash(ht.capacity)
+	}
+}
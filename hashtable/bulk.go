@@ -0,0 +1,147 @@
+package hashtable
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Pair es un par clave-valor.
+type Pair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// FromMap crea una nueva tabla de hash con los pares del map dado. La tabla se
+// crea con la capacidad necesaria para no redimensionarse durante la carga.
+func FromMap[K comparable, V any](m map[K]V) *HashTable[K, V] {
+	ht := NewHashTable[K, V](capacityFor(uint(len(m)), defaultLoadFactor), defaultLoadFactor)
+	for key, value := range m {
+		ht.Put(key, value)
+	}
+	return ht
+}
+
+// FromPairs crea una nueva tabla de hash con los pares dados. La tabla se crea
+// con la capacidad necesaria para no redimensionarse durante la carga.
+//
+// - Si una clave se repite, se conserva el último valor.
+func FromPairs[K comparable, V any](pairs ...Pair[K, V]) *HashTable[K, V] {
+	ht := NewHashTable[K, V](capacityFor(uint(len(pairs)), defaultLoadFactor), defaultLoadFactor)
+	for _, pair := range pairs {
+		ht.Put(pair.Key, pair.Value)
+	}
+	return ht
+}
+
+// ToMap devuelve un map con los pares de la tabla de hash.
+//
+// Es una función y no un método porque requiere que las claves sean
+// comparables, y la tabla admite claves de cualquier tipo.
+func ToMap[K comparable, V any](ht *HashTable[K, V]) map[K]V {
+	m := make(map[K]V, ht.size)
+	for i, node := range ht.buckets {
+		if ht.states[i] == SlotOccupied {
+			m[node.key] = node.value
+		}
+	}
+	return m
+}
+
+// Equal devuelve true si ambas tablas contienen los mismos pares clave-valor,
+// sin importar su capacidad ni la posición de cada par.
+//
+// Las claves de b se buscan en a con la función de igualdad de a, y las de a
+// en b con la de b, por lo que el resultado es simétrico aunque las tablas se
+// hayan creado con funciones de igualdad distintas.
+func Equal[K any, V comparable](a, b *HashTable[K, V]) bool {
+	return EqualFunc(a, b, func(x, y V) bool { return x == y })
+}
+
+// EqualFunc es como Equal, pero compara los valores con la función dada.
+func EqualFunc[K any, V any](a, b *HashTable[K, V], equal func(V, V) bool) bool {
+	return a.size == b.size && contains(a, b, equal) && contains(b, a, func(x, y V) bool { return equal(y, x) })
+}
+
+// contains devuelve true si todos los pares de b están en a. Las claves se
+// buscan con la función de igualdad de a.
+func contains[K any, V any](a, b *HashTable[K, V], equal func(V, V) bool) bool {
+	for i, node := range b.buckets {
+		if b.states[i] != SlotOccupied {
+			continue
+		}
+		index, exists := a.getIndex(node.key)
+		if !exists || !equal(a.buckets[index].value, node.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// PutAll agrega a la tabla todos los pares de other. Si una clave ya existe,
+// se reemplaza su valor por el de other.
+//
+// Antes de agregar los pares, la tabla se redimensiona una única vez para que
+// entren todos.
+//
+// - Si la tabla no puede contener todos los pares sin superar su capacidad
+// máxima, no agrega ninguno y devuelve un error que envuelve
+// ErrCapacityExceeded.
+//
+// - Si alguna clave no es válida para la tabla, devuelve el primer error,
+// luego de agregar el resto de los pares.
+func (ht *HashTable[K, V]) PutAll(other *HashTable[K, V]) error {
+	if err := ht.Reserve(ht.size + other.size); err != nil {
+		return err
+	}
+	var result error
+	for i, node := range other.buckets {
+		if other.states[i] == SlotOccupied {
+			if err := ht.TryPut(node.key, node.value); err != nil && result == nil {
+				result = err
+			}
+		}
+	}
+	return result
+}
+
+// Clone devuelve una copia de la tabla de hash, con la misma capacidad,
+// semilla y configuración. Los valores se copian de forma superficial.
+//
+// El observador no se copia.
+func (ht *HashTable[K, V]) Clone() *HashTable[K, V] {
+	clone := *ht
+	clone.buckets = slices.Clone(ht.buckets)
+	clone.states = slices.Clone(ht.states)
+	clone.canon = slices.Clone(ht.canon)
+	clone.observer = nil
+	return &clone
+}
+
+// Reserve redimensiona la tabla, si es necesario, para que pueda contener n
+// elementos sin volver a redimensionarse. Permite evitar los
+// redimensionamientos intermedios al agregar muchos elementos con Put.
+//
+// - Si para eso la tabla debe superar su capacidad máxima, no se redimensiona
+// y devuelve un error que envuelve ErrCapacityExceeded.
+func (ht *HashTable[K, V]) Reserve(n uint) error {
+	if n < ht.threshold {
+		return nil
+	}
+	capacity := capacityFor(n, ht.loadFactor)
+	if ht.maxCapacity > 0 && capacity > ht.maxCapacity {
+		return fmt.Errorf("%w: se necesitan %d posiciones y el máximo es %d", ErrCapacityExceeded, capacity, ht.maxCapacity)
+	}
+	ht.rehash(capacity)
+	ht.reseeded = false
+	return nil
+}
+
+// capacityFor devuelve la menor capacidad prima con la que una tabla con el
+// factor de carga dado puede contener n elementos sin redimensionarse.
+func capacityFor(n uint, loadFactor float32) uint {
+	capacity := nextPrime(uint(float32(n)/loadFactor) + 1)
+	for uint(float32(capacity)*loadFactor) <= n {
+		capacity = nextPrime(capacity + 1)
+	}
+	return capacity
+}
@@ -0,0 +1,177 @@
+package hashtable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMap(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 1000 {
+		m[fmt.Sprintf("clave-%d", i)] = i
+	}
+	ht := FromMap(m)
+
+	assert.Equal(t, uint(1000), ht.Size())
+	assert.Equal(t, m, ToMap(ht))
+}
+
+func TestFromMapCreaLaTablaConLaCapacidadNecesaria(t *testing.T) {
+	m := make(map[int]int)
+	var pairs []Pair[int, int]
+	for i := range 1000 {
+		m[i] = i
+		pairs = append(pairs, Pair[int, int]{i, i})
+	}
+	reserved := NewHashTable[int, int](0, 0)
+	reserved.Reserve(1000)
+
+	assert.Equal(t, reserved.Capacity(), FromMap(m).Capacity())
+	assert.Equal(t, reserved.Capacity(), FromPairs(pairs...).Capacity())
+}
+
+func TestFromMapClaveVacia(t *testing.T) {
+	ht := FromMap(map[string]int{"": 0, "uno": 1})
+
+	assert.Equal(t, map[string]int{"": 0, "uno": 1}, ToMap(ht))
+}
+
+func TestFromPairs(t *testing.T) {
+	ht := FromPairs(Pair[string, int]{"uno", 1}, Pair[string, int]{"dos", 2}, Pair[string, int]{"uno", 10})
+
+	assert.Equal(t, map[string]int{"uno": 10, "dos": 2}, ToMap(ht))
+}
+
+func TestReserveEvitaRedimensionar(t *testing.T) {
+	ht := NewHashTable[int, int](0, 0)
+	observer := &countingObserver{}
+	ht.SetObserver(observer)
+
+	// Un cambio de semilla ante una secuencia de prueba larga también se
+	// notifica como redimensionamiento, pero sin cambiar la capacidad.
+	grows := func() int {
+		n := 0
+		for _, event := range observer.resizes {
+			if event.oldCapacity != event.newCapacity {
+				n++
+			}
+		}
+		return n
+	}
+
+	ht.Reserve(1000)
+	require.Equal(t, 1, grows())
+	for i := 1; i <= 1000; i++ {
+		ht.Put(i, i)
+	}
+	ht.Put(1, 10)
+
+	assert.Equal(t, 1, grows())
+	assert.Equal(t, uint(1000), ht.Size())
+}
+
+func TestReserveNoAchica(t *testing.T) {
+	ht := NewHashTable[int, int](101, 0.5)
+
+	ht.Reserve(10)
+	assert.Equal(t, uint(101), ht.Capacity())
+}
+
+func TestReserveConservaElementos(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	ht.Put("uno", 1)
+	ht.Put("dos", 2)
+	ht.Remove("dos")
+
+	ht.Reserve(500)
+	assert.Greater(t, ht.Capacity(), uint(500))
+	assert.Equal(t, map[string]int{"uno": 1}, ToMap(ht))
+}
+
+func TestPutAll(t *testing.T) {
+	a := FromMap(map[string]int{"uno": 1, "dos": 2})
+	b := FromMap(map[string]int{"dos": 20, "tres": 3})
+	observer := &countingObserver{}
+	a.SetObserver(observer)
+
+	a.PutAll(b)
+	assert.Equal(t, map[string]int{"uno": 1, "dos": 20, "tres": 3}, ToMap(a))
+	assert.Equal(t, map[string]int{"dos": 20, "tres": 3}, ToMap(b))
+	assert.LessOrEqual(t, len(observer.resizes), 1)
+}
+
+func TestClone(t *testing.T) {
+	ht := FromMap(map[string]int{"uno": 1, "dos": 2})
+	clone := ht.Clone()
+
+	assert.True(t, Equal(ht, clone))
+	clone.Put("tres", 3)
+	clone.Remove("uno")
+	assert.Equal(t, map[string]int{"uno": 1, "dos": 2}, ToMap(ht))
+	assert.Equal(t, map[string]int{"dos": 2, "tres": 3}, ToMap(clone))
+}
+
+func TestCloneIndependienteDeLaSemilla(t *testing.T) {
+	ht := FromMap(map[string]int{"uno": 1})
+	clone := ht.Clone()
+
+	ht.reseed()
+	v, ok := clone.Get("uno")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func TestCloneFunc(t *testing.T) {
+	ht := NewNormalizedHashTable[int](0, 0, FoldCase)
+	ht.Put("Uno", 1)
+	clone := ht.Clone()
+
+	v, ok := clone.Get("UNO")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func TestEqual(t *testing.T) {
+	a := NewHashTable[string, int](5, 0.75)
+	b := NewHashTable[string, int](101, 0.5)
+	for i := range 3 {
+		a.Put(fmt.Sprint(i), i)
+		b.Put(fmt.Sprint(2-i), 2-i)
+	}
+
+	assert.True(t, Equal(a, b))
+	assert.True(t, Equal(b, a))
+	b.Put("1", 10)
+	assert.False(t, Equal(a, b))
+	b.Put("1", 1)
+	b.Put("3", 3)
+	assert.False(t, Equal(a, b))
+	b.Remove("3")
+	assert.True(t, Equal(a, b))
+}
+
+func TestEqualFunc(t *testing.T) {
+	a := FromMap(map[string][]int{"uno": {1}})
+	b := FromMap(map[string][]int{"uno": {1}})
+
+	assert.True(t, EqualFunc(a, b, func(x, y []int) bool { return fmt.Sprint(x) == fmt.Sprint(y) }))
+	assert.False(t, EqualFunc(a, b, func(x, y []int) bool { return false }))
+}
+
+func TestEqualConIgualdadesDistintas(t *testing.T) {
+	// a compara las claves sin distinguir mayúsculas; b las compara de forma
+	// exacta. "A" y "a" son la misma clave para a, pero no para b.
+	a := NewHashTableFunc[string, int](0, 0,
+		func(key string) uint64 { return uint64(len(key)) },
+		strings.EqualFold)
+	b := NewHashTable[string, int](0, 0)
+	a.Put("A", 1)
+	b.Put("a", 1)
+
+	assert.False(t, Equal(a, b))
+	assert.False(t, Equal(b, a))
+}
@@ -0,0 +1,20 @@
+package hashtable
+
+import "errors"
+
+var (
+	// ErrInvalidKey indica que la clave no puede agregarse a la tabla, por
+	// ejemplo porque no es igual a sí misma (NaN).
+	ErrInvalidKey = errors.New("hashtable: clave inválida")
+	// ErrNotFound indica que la clave no existe en la tabla.
+	ErrNotFound = errors.New("hashtable: clave inexistente")
+	// ErrCapacityExceeded indica que para agregar la clave la tabla debería
+	// superar su capacidad máxima (ver SetMaxCapacity).
+	ErrCapacityExceeded = errors.New("hashtable: capacidad máxima excedida")
+	// ErrConcurrentModification indica que la tabla se modificó durante una
+	// iteración, sin utilizar el iterador.
+	ErrConcurrentModification = errors.New("hashtable: la tabla se modificó durante la iteración")
+	// ErrNoCurrent indica que el iterador no está posicionado en un elemento,
+	// porque todavía no se llamó a Next o porque el elemento ya se eliminó.
+	ErrNoCurrent = errors.New("hashtable: el iterador no está posicionado en un elemento")
+)
@@ -0,0 +1,95 @@
+package hashtable
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryPutClaveInvalida(t *testing.T) {
+	ht := NewHashTable[float64, string](0, 0)
+
+	err := ht.TryPut(math.NaN(), "nan")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.False(t, ht.Put(math.NaN(), "nan"))
+	assert.True(t, ht.IsEmpty())
+
+	_, err = ht.TryGet(math.NaN())
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	assert.ErrorIs(t, ht.TryRemove(math.NaN()), ErrInvalidKey)
+}
+
+func TestTryGet(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	require.NoError(t, ht.TryPut("uno", 1))
+
+	v, err := ht.TryGet("uno")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, err = ht.TryGet("dos")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.False(t, errors.Is(err, ErrInvalidKey))
+	assert.ErrorContains(t, err, "dos")
+	assert.Equal(t, 0, v)
+}
+
+func TestMustGet(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	ht.Put("uno", 1)
+
+	assert.Equal(t, 1, ht.MustGet("uno"))
+	assert.PanicsWithError(t, "hashtable: clave inexistente: dos", func() {
+		ht.MustGet("dos")
+	})
+}
+
+func TestTryRemove(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	ht.Put("uno", 1)
+
+	assert.NoError(t, ht.TryRemove("uno"))
+	assert.ErrorIs(t, ht.TryRemove("uno"), ErrNotFound)
+}
+
+func TestMaxCapacity(t *testing.T) {
+	ht := NewHashTable[int, int](5, 0.75)
+	ht.SetMaxCapacity(10)
+
+	for i := range 5 {
+		require.NoError(t, ht.TryPut(i, i))
+	}
+	// La tabla crece de 5 a 7, el mayor primo que no supera el máximo.
+	assert.Equal(t, uint(7), ht.Capacity())
+
+	err := ht.TryPut(100, 100)
+	assert.ErrorIs(t, err, ErrCapacityExceeded)
+	assert.False(t, ht.Put(100, 100))
+	assert.Equal(t, uint(5), ht.Size())
+
+	// Las claves existentes pueden actualizarse y, al eliminar una, se puede
+	// agregar otra.
+	assert.NoError(t, ht.TryPut(0, 10))
+	assert.Equal(t, 10, ht.MustGet(0))
+	ht.Remove(1)
+	assert.NoError(t, ht.TryPut(100, 100))
+}
+
+func TestMaxCapacityReserve(t *testing.T) {
+	ht := NewHashTable[int, int](0, 0)
+	ht.SetMaxCapacity(100)
+
+	assert.ErrorIs(t, ht.Reserve(1000), ErrCapacityExceeded)
+	assert.Equal(t, uint(17), ht.Capacity())
+	assert.NoError(t, ht.Reserve(50))
+
+	other := NewHashTable[int, int](0, 0)
+	for i := range 200 {
+		other.Put(i, i)
+	}
+	assert.ErrorIs(t, ht.PutAll(other), ErrCapacityExceeded)
+	assert.True(t, ht.IsEmpty())
+}
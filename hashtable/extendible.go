@@ -0,0 +1,298 @@
+package hashtable
+
+import (
+	"fmt"
+	"hash/maphash"
+)
+
+// maxGlobalDepth es la profundidad global máxima de una ExtendibleHashTable,
+// que limita el directorio a 2^24 entradas. Si muchas claves comparten los
+// bits menos significativos del hash, duplicar el directorio no alcanza para
+// separarlas; a partir de esta profundidad, o si sus hashes coinciden en los
+// primeros maxGlobalDepth bits, el bucket desborda en páginas encadenadas.
+const maxGlobalDepth = 24
+
+// extendibleBucket es un bucket de una ExtendibleHashTable.
+type extendibleBucket[K comparable, V any] struct {
+	bucket[K, V]
+	// depth es la profundidad local: la cantidad de bits del hash que
+	// comparten todas las claves del bucket.
+	depth uint
+}
+
+// ExtendibleHashTable es una tabla de hash dinámica que utiliza hashing
+// extensible (Fagin et al., 1979).
+//
+// La tabla tiene un directorio de 2^d entradas, donde d es la profundidad
+// global, y cada entrada apunta a un bucket. La posición de una clave en el
+// directorio está dada por los d bits menos significativos de su hash. Varias
+// entradas pueden apuntar al mismo bucket: un bucket de profundidad local l
+// es apuntado por 2^(d-l) entradas.
+//
+// Cuando un bucket se llena, se divide en dos según el siguiente bit del hash
+// y solo se reparten sus pares. Si su profundidad local es igual a la global,
+// antes se duplica el directorio, lo que copia punteros pero no mueve ningún
+// par. Así, la tabla crece sin reubicar nunca todos sus elementos.
+//
+// Cuando el directorio alcanza su profundidad máxima, o las claves de un
+// bucket no pueden separarse, el bucket crece con páginas de desborde de
+// bucketSize pares en lugar de dividirse.
+//
+// La tabla no se achica al eliminar elementos.
+type ExtendibleHashTable[K comparable, V any] struct {
+	// directory es el directorio de la tabla, de 2^depth entradas.
+	directory []*extendibleBucket[K, V]
+	// buckets son los buckets distintos de la tabla.
+	buckets []*bucket[K, V]
+	// depth es la profundidad global.
+	depth uint
+	// size es el número de elementos en la tabla.
+	size uint
+	// bucketSize es la cantidad de elementos por bucket y por página de
+	// desborde.
+	bucketSize uint
+	// maxDepth es la profundidad global máxima.
+	maxDepth uint
+	// seed es la semilla utilizada para calcular el hash de las claves.
+	seed maphash.Seed
+}
+
+// NewExtendibleHashTable crea una nueva tabla de hashing extensible vacía.
+//
+// - Si bucketSize es igual a 0, se establece en DefaultBucketSize.
+func NewExtendibleHashTable[K comparable, V any](bucketSize uint) *ExtendibleHashTable[K, V] {
+	if bucketSize == 0 {
+		bucketSize = DefaultBucketSize
+	}
+	eh := &ExtendibleHashTable[K, V]{
+		bucketSize: bucketSize,
+		maxDepth:   maxGlobalDepth,
+		seed:       maphash.MakeSeed(),
+	}
+	eh.Clear()
+	return eh
+}
+
+// Put agrega un nuevo par clave-valor a la tabla. Si la clave ya existe,
+// actualiza el valor asociado a la clave.
+//
+// Devuelve true si se agregó o actualizó el elemento, false si la clave no es
+// válida.
+//
+// - Si el bucket de la clave está lleno, se divide, duplicando el directorio
+// si es necesario.
+func (eh *ExtendibleHashTable[K, V]) Put(key K, value V) bool {
+	return eh.TryPut(key, value) == nil
+}
+
+// TryPut es como Put, pero devuelve un error que envuelve ErrInvalidKey si la
+// clave no es igual a sí misma (NaN).
+func (eh *ExtendibleHashTable[K, V]) TryPut(key K, value V) error {
+	if key != key {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	h := eh.hash(key)
+	b := eh.directory[eh.index(h)]
+	if page, i := b.lookup(key); i >= 0 {
+		page.entries[i].value = value
+		return nil
+	}
+	for uint(len(b.entries)) >= eh.bucketSize && b.depth < eh.maxDepth && eh.separable(b, h) {
+		eh.splitBucket(b)
+		b = eh.directory[eh.index(h)]
+	}
+	b.insert(hashTableEntry[K, V]{key: key, value: value}, eh.bucketSize)
+	eh.size++
+	return nil
+}
+
+// Get devuelve el valor asociado a la clave dada y true para indicar que
+// encontró la clave buscada.
+//
+// - Si la clave no existe, devuelve false y un valor nulo.
+func (eh *ExtendibleHashTable[K, V]) Get(key K) (V, bool) {
+	b := eh.directory[eh.index(eh.hash(key))]
+	if page, i := b.lookup(key); i >= 0 {
+		return page.entries[i].value, true
+	}
+	var zeroValue V
+	return zeroValue, false
+}
+
+// TryGet es como Get, pero devuelve un error que envuelve ErrNotFound si no
+// encuentra la clave, o ErrInvalidKey si la clave no es válida.
+func (eh *ExtendibleHashTable[K, V]) TryGet(key K) (V, error) {
+	var zeroValue V
+	if key != key {
+		return zeroValue, fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	value, exists := eh.Get(key)
+	if !exists {
+		return zeroValue, fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	return value, nil
+}
+
+// MustGet es como Get, pero entra en pánico si no encuentra la clave.
+func (eh *ExtendibleHashTable[K, V]) MustGet(key K) V {
+	value, err := eh.TryGet(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Remove elimina el par clave-valor asociado a la clave dada.
+//
+// Devuelve true si se eliminó el elemento, false si la clave no existe.
+func (eh *ExtendibleHashTable[K, V]) Remove(key K) bool {
+	b := eh.directory[eh.index(eh.hash(key))]
+	page, i := b.lookup(key)
+	if i < 0 {
+		return false
+	}
+	b.delete(page, i)
+	eh.size--
+	return true
+}
+
+// TryRemove es como Remove, pero devuelve un error que envuelve ErrNotFound si
+// no encuentra la clave, o ErrInvalidKey si la clave no es válida.
+func (eh *ExtendibleHashTable[K, V]) TryRemove(key K) error {
+	if key != key {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	if !eh.Remove(key) {
+		return fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	return nil
+}
+
+// Keys devuelve una lista de todas las claves en la tabla.
+func (eh *ExtendibleHashTable[K, V]) Keys() []K {
+	keys := make([]K, 0, eh.size)
+	for _, b := range eh.buckets {
+		for page := b; page != nil; page = page.overflow {
+			for _, entry := range page.entries {
+				keys = append(keys, entry.key)
+			}
+		}
+	}
+	return keys
+}
+
+// Values devuelve una lista de todos los valores en la tabla.
+func (eh *ExtendibleHashTable[K, V]) Values() []V {
+	values := make([]V, 0, eh.size)
+	for _, b := range eh.buckets {
+		for page := b; page != nil; page = page.overflow {
+			for _, entry := range page.entries {
+				values = append(values, entry.value)
+			}
+		}
+	}
+	return values
+}
+
+// Size devuelve el número de elementos en la tabla.
+func (eh *ExtendibleHashTable[K, V]) Size() uint {
+	return eh.size
+}
+
+// IsEmpty devuelve true si la tabla está vacía, false en caso contrario.
+func (eh *ExtendibleHashTable[K, V]) IsEmpty() bool {
+	return eh.size == 0
+}
+
+// Clear elimina todos los elementos de la tabla y vuelve a un directorio de
+// una única entrada.
+func (eh *ExtendibleHashTable[K, V]) Clear() {
+	b := &extendibleBucket[K, V]{}
+	eh.directory = []*extendibleBucket[K, V]{b}
+	eh.buckets = []*bucket[K, V]{&b.bucket}
+	eh.depth = 0
+	eh.size = 0
+}
+
+// GlobalDepth devuelve la profundidad global de la tabla: el directorio tiene
+// 2^GlobalDepth entradas.
+func (eh *ExtendibleHashTable[K, V]) GlobalDepth() uint {
+	return eh.depth
+}
+
+// Buckets devuelve la cantidad de buckets distintos de la tabla, sin contar
+// las páginas de desborde.
+func (eh *ExtendibleHashTable[K, V]) Buckets() uint {
+	return uint(len(eh.buckets))
+}
+
+// OverflowPages devuelve la cantidad de páginas de desborde de la tabla.
+func (eh *ExtendibleHashTable[K, V]) OverflowPages() uint {
+	var pages uint
+	for _, b := range eh.buckets {
+		pages += b.overflowPages()
+	}
+	return pages
+}
+
+// String devuelve una representación en cadena de la tabla.
+func (eh *ExtendibleHashTable[K, V]) String() string {
+	return formatEntries(eh.buckets)
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// hash calcula el hash de una clave.
+func (eh *ExtendibleHashTable[K, V]) hash(key K) uint64 {
+	return maphash.Comparable(eh.seed, key)
+}
+
+// index devuelve la entrada del directorio que le corresponde a un hash.
+func (eh *ExtendibleHashTable[K, V]) index(h uint64) uint64 {
+	return h & (1<<eh.depth - 1)
+}
+
+// separable devuelve true si alguna clave del bucket tiene un hash distinto
+// de h en los bits que puede usar el directorio. Si no, dividir el bucket no
+// separa a la nueva clave de las demás.
+func (eh *ExtendibleHashTable[K, V]) separable(b *extendibleBucket[K, V], h uint64) bool {
+	mask := uint64(1)<<eh.maxDepth - 1
+	for page := &b.bucket; page != nil; page = page.overflow {
+		for _, entry := range page.entries {
+			if (eh.hash(entry.key)^h)&mask != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitBucket divide un bucket en dos según el bit de su profundidad local,
+// duplicando antes el directorio si la profundidad local es igual a la global.
+func (eh *ExtendibleHashTable[K, V]) splitBucket(old *extendibleBucket[K, V]) {
+	if old.depth == eh.depth {
+		eh.directory = append(eh.directory, eh.directory...)
+		eh.depth++
+	}
+
+	bit := uint64(1) << old.depth
+	sibling := &extendibleBucket[K, V]{depth: old.depth + 1}
+	old.depth++
+	eh.buckets = append(eh.buckets, &sibling.bucket)
+
+	// Las entradas del directorio que apuntaban al bucket y tienen el bit en
+	// 1 pasan a apuntar al nuevo bucket.
+	for i, b := range eh.directory {
+		if b == old && uint64(i)&bit != 0 {
+			eh.directory[i] = sibling
+		}
+	}
+
+	for _, entry := range old.drain() {
+		if eh.hash(entry.key)&bit != 0 {
+			sibling.insert(entry, eh.bucketSize)
+		} else {
+			old.insert(entry, eh.bucketSize)
+		}
+	}
+}
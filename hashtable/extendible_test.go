@@ -0,0 +1,100 @@
+package hashtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checkExtendible verifica los invariantes de una ExtendibleHashTable.
+func checkExtendible[K comparable, V any](t *testing.T, eh *ExtendibleHashTable[K, V]) {
+	t.Helper()
+	assert.Len(t, eh.directory, 1<<eh.depth)
+	refs := make(map[*extendibleBucket[K, V]]int)
+	for i, b := range eh.directory {
+		refs[b]++
+		assert.LessOrEqual(t, b.depth, eh.depth)
+		for page := &b.bucket; page != nil; page = page.overflow {
+			for _, entry := range page.entries {
+				mask := uint64(1)<<b.depth - 1
+				assert.Equal(t, uint64(i)&mask, eh.hash(entry.key)&mask)
+			}
+		}
+	}
+	assert.Len(t, refs, len(eh.buckets))
+	assert.LessOrEqual(t, eh.depth, eh.maxDepth)
+	for b, n := range refs {
+		// Un bucket de profundidad local l es apuntado por 2^(d-l) entradas.
+		assert.Equal(t, 1<<(eh.depth-b.depth), n)
+		for page := &b.bucket; page != nil; page = page.overflow {
+			assert.LessOrEqual(t, uint(len(page.entries)), eh.bucketSize)
+			if page != &b.bucket {
+				assert.NotEmpty(t, page.entries)
+			}
+		}
+	}
+}
+
+func TestExtendibleHashTableInvariantes(t *testing.T) {
+	eh := NewExtendibleHashTable[int, int](2)
+	for i := range 500 {
+		eh.Put(i, i)
+	}
+
+	checkExtendible(t, eh)
+	assert.Greater(t, eh.GlobalDepth(), uint(0))
+	for i := range 500 {
+		assert.Equal(t, i, eh.MustGet(i))
+	}
+}
+
+func TestExtendibleHashTableDuplicaDirectorio(t *testing.T) {
+	eh := NewExtendibleHashTable[int, int](1)
+	assert.Equal(t, uint(0), eh.GlobalDepth())
+
+	eh.Put(1, 1)
+	assert.Equal(t, uint(0), eh.GlobalDepth())
+	eh.Put(2, 2)
+	assert.GreaterOrEqual(t, eh.GlobalDepth(), uint(1))
+	assert.GreaterOrEqual(t, eh.Buckets(), uint(2))
+	checkExtendible(t, eh)
+}
+
+func TestExtendibleHashTableProfundidadMaxima(t *testing.T) {
+	eh := NewExtendibleHashTable[int, int](1)
+	eh.maxDepth = 3
+	for i := range 200 {
+		eh.Put(i, i)
+	}
+
+	// Al alcanzar la profundidad máxima, el directorio deja de duplicarse y
+	// los buckets desbordan en páginas.
+	checkExtendible(t, eh)
+	assert.Equal(t, uint(3), eh.GlobalDepth())
+	assert.Positive(t, eh.OverflowPages())
+	assert.Len(t, eh.Keys(), 200)
+	for i := range 200 {
+		assert.Equal(t, i, eh.MustGet(i))
+	}
+
+	for i := range 150 {
+		assert.True(t, eh.Remove(i))
+	}
+	checkExtendible(t, eh)
+	assert.Equal(t, uint(50), eh.Size())
+	for i := 150; i < 200; i++ {
+		assert.Equal(t, i, eh.MustGet(i))
+	}
+}
+
+func TestExtendibleHashTableClear(t *testing.T) {
+	eh := NewExtendibleHashTable[int, int](0)
+	for i := range 100 {
+		eh.Put(i, i)
+	}
+
+	eh.Clear()
+	assert.True(t, eh.IsEmpty())
+	assert.Equal(t, uint(0), eh.GlobalDepth())
+	assert.Equal(t, uint(1), eh.Buckets())
+}
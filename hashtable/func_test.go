@@ -0,0 +1,175 @@
+package hashtable
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// point es una clave que implementa Hashable.
+type point struct {
+	x, y int
+}
+
+func (p point) Hash() uint64 {
+	return hashing.Mix64(uint64(p.x)<<32 ^ uint64(uint32(p.y)))
+}
+
+func (p point) Equal(other point) bool {
+	return p == other
+}
+
+// person es una clave cuyo nombre se compara sin distinguir mayúsculas de
+// minúsculas.
+type person struct {
+	name string
+	age  int
+}
+
+func hashPerson(p person) uint64 {
+	return hashing.String64(strings.ToLower(p.name), uint64(p.age))
+}
+
+func equalPerson(a, b person) bool {
+	return strings.EqualFold(a.name, b.name) && a.age == b.age
+}
+
+func TestHashTableFuncBytes(t *testing.T) {
+	ht := NewHashTableFunc[[]byte, int](0, 0,
+		func(k []byte) uint64 { return hashing.Sum64(k, 0) },
+		bytes.Equal)
+
+	assert.True(t, ht.Put([]byte("uno"), 1))
+	assert.True(t, ht.Put([]byte("uno"), 10))
+	assert.True(t, ht.Put(nil, 0))
+
+	v, ok := ht.Get([]byte("uno"))
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	_, ok = ht.Get([]byte{})
+	assert.True(t, ok, "nil y el slice vacío son iguales según bytes.Equal")
+	assert.Equal(t, uint(2), ht.Size())
+}
+
+func TestHashTableFuncSliceInt(t *testing.T) {
+	hash := func(k []int) uint64 {
+		var h uint64
+		for _, n := range k {
+			h = hashing.Mix64(h ^ uint64(n))
+		}
+		return h
+	}
+	ht := NewHashTableFunc[[]int, string](0, 0, hash, slices.Equal[[]int])
+	for i := range 100 {
+		ht.Put([]int{i, i * i}, fmt.Sprint(i))
+	}
+
+	assert.Equal(t, uint(100), ht.Size())
+	for i := range 100 {
+		v, ok := ht.Get([]int{i, i * i})
+		assert.True(t, ok)
+		assert.Equal(t, fmt.Sprint(i), v)
+	}
+	assert.True(t, ht.Remove([]int{3, 9}))
+	_, ok := ht.Get([]int{3, 9})
+	assert.False(t, ok)
+}
+
+func TestHashTableFuncCamposNormalizados(t *testing.T) {
+	ht := NewHashTableFunc[person, int](0, 0, hashPerson, equalPerson)
+	ht.Put(person{"Ana", 30}, 1)
+	ht.Put(person{"ANA", 30}, 2)
+	ht.Put(person{"ana", 31}, 3)
+
+	assert.Equal(t, uint(2), ht.Size())
+	v, ok := ht.Get(person{"aNa", 30})
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, []person{{"Ana", 30}}, filterAge(ht.Keys(), 30), "se conserva la clave original")
+}
+
+func TestHashTableFuncColisiones(t *testing.T) {
+	ht := NewHashTableFunc[string, int](0, 0,
+		func(string) uint64 { return 42 },
+		func(a, b string) bool { return a == b })
+	for i := range 50 {
+		ht.Put(fmt.Sprint(i), i)
+	}
+
+	assert.Equal(t, uint(50), ht.Size())
+	for i := range 50 {
+		v, ok := ht.Get(fmt.Sprint(i))
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestHashTableFuncCambiaSemilla(t *testing.T) {
+	ht := NewHashTableFunc[string, int](0, 0, func(k string) uint64 { return hashing.String64(k, 0) },
+		func(a, b string) bool { return a == b })
+	home := ht.hash("hola")
+	for range 10 {
+		ht.reseed()
+		if ht.hash("hola") != home {
+			return
+		}
+	}
+	t.Fatal("el hash no depende de la semilla de la tabla")
+}
+
+func TestHashTableFuncNil(t *testing.T) {
+	assert.Panics(t, func() {
+		NewHashTableFunc[[]byte, int](0, 0, nil, bytes.Equal)
+	})
+	assert.Panics(t, func() {
+		NewHashTableFunc[[]byte, int](0, 0, func([]byte) uint64 { return 0 }, nil)
+	})
+}
+
+func TestHashableHashTable(t *testing.T) {
+	ht := NewHashableHashTable[point, string](0, 0)
+	for x := range 20 {
+		for y := range 20 {
+			ht.Put(point{x, y}, fmt.Sprintf("%d,%d", x, y))
+		}
+	}
+
+	require.Equal(t, uint(400), ht.Size())
+	v, ok := ht.Get(point{7, 13})
+	assert.True(t, ok)
+	assert.Equal(t, "7,13", v)
+	assert.True(t, ht.Put(point{0, 0}, "origen"), "el valor nulo es una clave válida")
+	v, _ = ht.Get(point{})
+	assert.Equal(t, "origen", v)
+}
+
+func filterAge(people []person, age int) []person {
+	var result []person
+	for _, p := range people {
+		if p.age == age {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func TestNewHashTableLike(t *testing.T) {
+	ht := NewNormalizedHashTable[int](0, 0, FoldCase)
+	ht.Put("Uno", 1)
+	like := NewHashTableLike[string](ht)
+
+	assert.True(t, like.IsEmpty())
+	like.Put("UNO", "one")
+	v, ok := like.Get("uno")
+	assert.True(t, ok)
+	assert.Equal(t, "one", v)
+	assert.False(t, NewHashTableLike[bool](NewHashTable[float64, int](0, 0)).Put(math.NaN(), true))
+}
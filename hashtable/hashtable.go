@@ -1,36 +1,113 @@
 // hashtable proporciona una implementación de una tabla hash cerrada cuyas
-// claves son cadenas de caracteres y los valores pueden ser de cualquier tipo.
-// La tabla utiliza un arreglo para almacenar pares clave-valor.
+// claves y valores pueden ser de cualquier tipo. La tabla utiliza un arreglo
+// para almacenar pares clave-valor.
+//
+// Las claves de tipos comparables utilizan el operador == y el hash del
+// paquete maphash. Para otros tipos, como slices, o para comparar claves de
+// otra forma (por ejemplo, sin distinguir mayúsculas de minúsculas), se puede
+// indicar una función de hash y una de igualdad con NewHashTableFunc, o
+// implementar la interfaz Hashable y usar NewHashableHashTable.
+//
+// Cada tabla calcula el hash de sus claves con una semilla aleatoria propia,
+// de modo que no es posible elegir de antemano claves que colisionen. Si aun
+// así se detecta una secuencia de prueba demasiado larga, la tabla elige una
+// nueva semilla y reubica todos sus elementos.
 package hashtable
 
 import (
 	"fmt"
+	"hash/maphash"
 	"math"
+	"time"
 )
 
 // hashTableEntry representa una entrada en la tabla hash, que contiene una
 // clave y su valor asociado.
-type hashTableEntry[K string, V any] struct {
+type hashTableEntry[K any, V any] struct {
 	key   K
 	value V
 }
 
 // HashTable es una tabla hash cerrada que utiliza un arreglo para almacenar
-// elementos. La tabla solo soporta string como claves y cualquier tipo como
-// valores. En cada posición del arreglo se almacena un par clave-valor.
-type HashTable[K string, V any] struct {
+// elementos. La tabla soporta cualquier tipo como claves y como valores. En
+// cada posición del arreglo se almacena un par clave-valor.
+//
+// Los pares se guardan directamente en el arreglo, sin un puntero por entrada,
+// y el estado de cada posición (vacía, ocupada o eliminada) se guarda en un
+// arreglo separado de un byte por posición.
+//
+// El estado de cada posición se registra por separado de la clave, por lo que
+// el valor nulo del tipo de las claves (por ejemplo "" o 0) es una clave
+// válida como cualquier otra.
+//
+// Las operaciones tienen dos variantes: Put, Get y Remove informan el
+// resultado con un booleano, mientras que TryPut, TryGet y TryRemove devuelven
+// un error que envuelve ErrInvalidKey, ErrNotFound o ErrCapacityExceeded, y
+// puede inspeccionarse con errors.Is.
+type HashTable[K any, V any] struct {
 	// arreglo de entradas de la tabla hash.
-	buckets []*hashTableEntry[K, V]
+	buckets []hashTableEntry[K, V]
+	// states es el estado de cada posición de buckets.
+	states []SlotState
 	// size es el número de elementos en la tabla.
 	size uint
+	// deleted es el número de posiciones eliminadas. Como las búsquedas solo
+	// se detienen en posiciones vacías, cuentan como usadas para decidir
+	// cuándo reubicar los elementos.
+	deleted uint
 	// capacity es la capacidad de la tabla.
 	capacity uint
 	// loadFactor es el factor de carga de la tabla.
 	loadFactor float32
 	// threshold es el umbral de carga para redimensionar la tabla.
 	threshold uint
+	// seed es la semilla utilizada para calcular el hash de las claves.
+	seed maphash.Seed
+	// hashKey calcula el hash de una clave con la semilla dada.
+	hashKey func(maphash.Seed, K) uint64
+	// equal compara dos claves.
+	equal func(K, K) bool
+	// invalid devuelve true para las claves que no pueden agregarse a la
+	// tabla. Si es nil, todas las claves son válidas.
+	invalid func(K) bool
+	// canonical devuelve la forma de una clave con la que se calcula su hash
+	// y se compara con las demás. Si es nil, se utiliza la clave tal como es.
+	canonical func(K) K
+	// canon es la forma canónica de la clave de cada posición de buckets, que
+	// se calcula una única vez al agregarla. Solo se utiliza si canonical no
+	// es nil.
+	canon []K
+	// legacy indica si las claves string utilizan el hash polinómico, que es
+	// determinístico, en lugar de la semilla.
+	legacy bool
+	// maxProbeLength es la longitud de prueba a partir de la cual se cambia la
+	// semilla de la tabla. Si es 0, no se controla.
+	maxProbeLength uint
+	// maxCapacity es la capacidad máxima de la tabla. Si es 0, no tiene límite.
+	maxCapacity uint
+	// reseeds es la cantidad de veces que se cambió la semilla.
+	reseeds uint
+	// reseeded indica si ya se cambió la semilla desde el último
+	// redimensionamiento. Con una semilla aleatoria no es posible fabricar
+	// colisiones, por lo que una nueva secuencia larga con la misma capacidad
+	// se debe al azar y no justifica volver a cambiarla.
+	reseeded bool
+	// observer recibe las notificaciones de la tabla, si no es nil.
+	observer Observer
+	// modCount es la cantidad de modificaciones estructurales de la tabla:
+	// agregar o eliminar claves y reubicar los elementos. Los iteradores la
+	// utilizan para detectar modificaciones durante la iteración.
+	modCount uint
 }
 
+// defaultLoadFactor es el factor de carga que se utiliza cuando no se indica
+// uno válido.
+const defaultLoadFactor = 0.75
+
+// DefaultMaxProbeLength es la longitud de prueba máxima por defecto, a partir
+// de la cual la tabla cambia su semilla.
+const DefaultMaxProbeLength = 64
+
 // NewHashTable crea una nueva tabla de hash cerrada con la capacidad y el
 // factor de carga especificados.
 //
@@ -41,64 +118,208 @@ type HashTable[K string, V any] struct {
 //
 // - Si la capacidad no es un número primo, se redimensiona a la siguiente
 // capacidad primo mayor o igual a la capacidad especificada.
-func NewHashTable[K string, V any](capacity uint, loadFactor float32) *HashTable[K, V] {
-	if capacity == 0 {
-		capacity = 17
-	}
-	if loadFactor <= 0 || loadFactor > 1 {
-		loadFactor = 0.75
-	}
-	if !isPrime(capacity) {
-		capacity = nextPrime(capacity)
+//
+// Una clave que no es igual a sí misma, como NaN, no es una clave válida, ya
+// que no podría volver a encontrarse.
+func NewHashTable[K comparable, V any](capacity uint, loadFactor float32) *HashTable[K, V] {
+	ht := newHashTable[K, V](capacity, loadFactor)
+	ht.hashKey = maphash.Comparable[K]
+	ht.equal = func(a, b K) bool { return a == b }
+	ht.invalid = func(key K) bool { return key != key }
+	return ht
+}
+
+// NewHashTableFunc crea una nueva tabla de hash cerrada con la capacidad y el
+// factor de carga especificados, que utiliza las funciones dadas para calcular
+// el hash de las claves y compararlas. Permite utilizar claves de tipos no
+// comparables, como slices, o claves que se comparan de otra forma.
+//
+// Las funciones deben ser consistentes: si equal(a, b) es true, hash(a) debe
+// ser igual a hash(b). El resultado de hash se combina con la semilla de la
+// tabla, pero si hash devuelve el mismo valor para muchas claves distintas,
+// cambiar la semilla no evita las colisiones.
+//
+// La capacidad y el factor de carga se ajustan igual que en NewHashTable.
+//
+// Uso:
+//
+//	ht := hashtable.NewHashTableFunc[[]byte, int](0, 0,
+//		func(k []byte) uint64 { return hashing.Sum64(k, 0) },
+//		bytes.Equal)
+//
+// - Si hash o equal son nil, entra en pánico.
+func NewHashTableFunc[K any, V any](capacity uint, loadFactor float32, hash func(K) uint64, equal func(K, K) bool) *HashTable[K, V] {
+	if hash == nil || equal == nil {
+		panic("hashtable: las funciones de hash e igualdad no pueden ser nil")
 	}
-	return &HashTable[K, V]{
-		buckets:    make([]*hashTableEntry[K, V], capacity),
-		size:       0,
-		capacity:   capacity,
-		loadFactor: loadFactor,
-		threshold:  uint(float32(capacity) * loadFactor),
+	ht := newHashTable[K, V](capacity, loadFactor)
+	ht.hashKey = func(seed maphash.Seed, key K) uint64 { return maphash.Comparable(seed, hash(key)) }
+	ht.equal = equal
+	return ht
+}
+
+// NewHashableHashTable crea una nueva tabla de hash cerrada cuyas claves
+// calculan su propio hash y se comparan con los métodos de la interfaz
+// Hashable.
+//
+// La capacidad y el factor de carga se ajustan igual que en NewHashTable.
+func NewHashableHashTable[K Hashable[K], V any](capacity uint, loadFactor float32) *HashTable[K, V] {
+	return NewHashTableFunc[K, V](capacity, loadFactor,
+		func(key K) uint64 { return key.Hash() },
+		func(a, b K) bool { return a.Equal(b) })
+}
+
+// NewHashTableLike crea una nueva tabla de hash vacía, con la capacidad y el
+// factor de carga por defecto, cuyas claves se comparan y se distribuyen igual
+// que en other: con las mismas funciones de hash e igualdad y la misma
+// validación de claves. Los valores pueden ser de otro tipo.
+//
+// Sirve para construir tablas auxiliares que deben reconocer las mismas
+// claves que otra, por ejemplo si other normaliza sus claves.
+func NewHashTableLike[W any, K any, V any](other *HashTable[K, V]) *HashTable[K, W] {
+	ht := newHashTable[K, W](0, 0)
+	ht.hashKey = other.hashKey
+	ht.equal = other.equal
+	ht.invalid = other.invalid
+	ht.canonical = other.canonical
+	if ht.canonical != nil {
+		ht.canon = make([]K, ht.capacity)
 	}
+	ht.legacy = other.legacy
+	ht.maxProbeLength = other.maxProbeLength
+	return ht
+}
+
+// Equaler es implementada por los tipos que saben compararse con otro valor
+// del tipo K.
+type Equaler[K any] interface {
+	// Equal devuelve true si el receptor es igual a other.
+	Equal(other K) bool
+}
+
+// Hashable es implementada por los tipos que pueden usarse como claves de una
+// tabla creada con NewHashableHashTable. Si a.Equal(b) es true, a.Hash() debe
+// ser igual a b.Hash().
+type Hashable[K any] interface {
+	Equaler[K]
+	// Hash devuelve el hash del receptor.
+	Hash() uint64
+}
+
+// NewLegacyHashTable crea una nueva tabla de hash cerrada que calcula el hash
+// de las claves string con la técnica de Multiplicación Polinómica, sin
+// semilla, como las versiones anteriores de la tabla.
+//
+// El hash polinómico es determinístico y permite fabricar claves que
+// colisionan. Si se detecta una secuencia de prueba más larga que la máxima
+// permitida, la tabla pasa a utilizar una semilla aleatoria.
+func NewLegacyHashTable[K comparable, V any](capacity uint, loadFactor float32) *HashTable[K, V] {
+	ht := NewHashTable[K, V](capacity, loadFactor)
+	ht.legacy = true
+	return ht
 }
 
 // Put agrega un nuevo par clave-valor a la tabla de hash. Si la clave ya
 // existe, actualiza el valor asociado a la clave.
 //
-// Devuelve true si se agregó o actualizó el elemento, false si la clave es nula.
+// Devuelve true si se agregó o actualizó el elemento, false si la clave no es
+// válida o no hay lugar para agregarla (ver TryPut).
 //
-// - Si la tabla de hash está llena, se redimensiona automáticamente.
+// - Si la tabla de hash está llena, se redimensiona automáticamente. Las
+// posiciones eliminadas cuentan como ocupadas; si son la mayoría, los
+// elementos se reubican sin cambiar la capacidad.
 //
-// - Si la clave es nula, no se agrega nada.
+// - Si la secuencia de prueba supera la longitud máxima, se cambia la semilla
+// de la tabla (ver SetMaxProbeLength).
+//
+// - Si la clave no existe, se agrega en la primera posición eliminada de su
+// secuencia de prueba, o en la primera vacía si no hay ninguna eliminada.
 func (ht *HashTable[K, V]) Put(key K, value V) bool {
-	// Si la clave es nula, no se agrega nada.
-	if key == "" {
-		return false
+	return ht.TryPut(key, value) == nil
+}
+
+// TryPut es como Put, pero devuelve un error que indica por qué no se agregó
+// el elemento.
+//
+// - Si la clave no es válida, devuelve un error que envuelve ErrInvalidKey.
+//
+// - Si la clave no existe y la tabla no puede redimensionarse sin superar su
+// capacidad máxima, devuelve un error que envuelve ErrCapacityExceeded.
+func (ht *HashTable[K, V]) TryPut(key K, value V) error {
+	// Si la clave no es válida, no se agrega nada.
+	if ht.invalid != nil && ht.invalid(key) {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, key)
 	}
-	// Si la tabla de hash está llena, redimensionamos.
-	if ht.size >= ht.threshold {
-		ht.resize()
+	// Si la tabla de hash está llena, contando las posiciones eliminadas,
+	// liberamos lugar. Si no es posible, solo se puede actualizar una clave
+	// existente.
+	if ht.size+ht.deleted >= ht.threshold {
+		if err := ht.makeRoom(); err != nil {
+			index, exists := ht.getIndex(key)
+			if !exists {
+				return err
+			}
+			ht.buckets[index].value = value
+			return nil
+		}
 	}
 
-	index := ht.hash(key) % ht.capacity
-	for {
-		if ht.buckets[index] == nil || ht.buckets[index].key == "" {
-			// Si el bucket está vacío o la clave es nula, insertamos el nuevo elemento.
-			ht.buckets[index] = &hashTableEntry[K, V]{key: key, value: value}
-			ht.size++
-			return true
-		} else if ht.buckets[index].key == key {
-			// Si la clave ya existe, actualizamos el valor.
-			ht.buckets[index].value = value
-			return true
+	// Recorremos la secuencia de prueba hasta encontrar la clave o una
+	// posición vacía, recordando la primera posición libre. No alcanza con
+	// detenerse en la primera posición eliminada, porque la clave puede estar
+	// más adelante.
+	canonical := ht.canonicalize(key)
+	index := ht.hash(canonical) % ht.capacity
+	free, freeProbe, hasFree := uint(0), uint(0), false
+	for probe := uint(0); probe < ht.capacity; probe++ {
+		if ht.observer != nil {
+			ht.observer.Probe(index, probe)
+		}
+		if ht.states[index] == SlotOccupied {
+			if ht.equal(ht.probeKey(index), canonical) {
+				// Si la clave ya existe, actualizamos el valor.
+				ht.buckets[index].value = value
+				return nil
+			}
+			if ht.observer != nil {
+				ht.observer.Collision(index)
+			}
+		} else if !hasFree {
+			free, freeProbe, hasFree = index, probe, true
+		}
+		if ht.states[index] == SlotEmpty {
+			break
 		}
-		// Si el bucket está ocupado y la clave no coincide, probamos el siguiente índice.
+		// Si el bucket está ocupado o fue eliminado, probamos el siguiente índice.
 		index = (index + 1) % ht.capacity
 	}
+
+	// Como la cantidad de elementos es menor que el umbral, siempre hay una
+	// posición libre.
+	if ht.states[free] == SlotDeleted {
+		ht.deleted--
+		if ht.observer != nil {
+			ht.observer.TombstoneReused(free)
+		}
+	}
+	ht.buckets[free] = hashTableEntry[K, V]{key: key, value: value}
+	if ht.canon != nil {
+		ht.canon[free] = canonical
+	}
+	ht.states[free] = SlotOccupied
+	ht.size++
+	ht.modCount++
+	// Si la secuencia de prueba es demasiado larga, cambiamos la semilla.
+	if ht.maxProbeLength > 0 && freeProbe > ht.maxProbeLength && !ht.reseeded {
+		ht.reseed()
+	}
+	return nil
 }
 
 // Get devuelve el valor asociado a la clave dada y true para indicar que
 // encontró la clave buscada.
 //
-// - Si la clave no existe o es nula, devuelve false y un valor nulo.
+// - Si la clave no existe, devuelve false y un valor nulo.
 func (ht *HashTable[K, V]) Get(key K) (V, bool) {
 	index, exists := ht.getIndex(key)
 	if !exists {
@@ -108,25 +329,63 @@ func (ht *HashTable[K, V]) Get(key K) (V, bool) {
 	return ht.buckets[index].value, exists
 }
 
+// TryGet es como Get, pero devuelve un error si no encuentra la clave.
+//
+// - Si la clave no es válida, devuelve un error que envuelve ErrInvalidKey.
+//
+// - Si la clave no existe, devuelve un error que envuelve ErrNotFound.
+func (ht *HashTable[K, V]) TryGet(key K) (V, error) {
+	var zeroValue V
+	if ht.invalid != nil && ht.invalid(key) {
+		return zeroValue, fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	index, exists := ht.getIndex(key)
+	if !exists {
+		return zeroValue, fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	return ht.buckets[index].value, nil
+}
+
+// MustGet es como Get, pero entra en pánico si no encuentra la clave.
+func (ht *HashTable[K, V]) MustGet(key K) V {
+	value, err := ht.TryGet(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
 // Remove elimina el par clave-valor asociado a la clave dada.
 //
 // Devuelve true si se eliminó el elemento, false si la clave no existe.
 func (ht *HashTable[K, V]) Remove(key K) bool {
 	index, exists := ht.getIndex(key)
 	if exists {
-		var zeroValue V
-		ht.buckets[index].key = "" //marca la clave como nula para indicar que fue eliminada
-		ht.buckets[index].value = zeroValue
-		ht.size--
+		ht.removeAt(index)
 	}
 	return exists
 }
 
+// TryRemove es como Remove, pero devuelve un error si no encuentra la clave.
+//
+// - Si la clave no es válida, devuelve un error que envuelve ErrInvalidKey.
+//
+// - Si la clave no existe, devuelve un error que envuelve ErrNotFound.
+func (ht *HashTable[K, V]) TryRemove(key K) error {
+	if ht.invalid != nil && ht.invalid(key) {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	if !ht.Remove(key) {
+		return fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	return nil
+}
+
 // Keys devuelve una lista de todas las claves en la tabla de hash.
 func (ht *HashTable[K, V]) Keys() []K {
 	keys := make([]K, 0, ht.size)
-	for _, node := range ht.buckets {
-		if node != nil && node.key != "" {
+	for i, node := range ht.buckets {
+		if ht.states[i] == SlotOccupied {
 			keys = append(keys, node.key)
 		}
 	}
@@ -136,8 +395,8 @@ func (ht *HashTable[K, V]) Keys() []K {
 // Values devuelve una lista de todos los valores en la tabla de hash.
 func (ht *HashTable[K, V]) Values() []V {
 	values := make([]V, 0, ht.size)
-	for _, node := range ht.buckets {
-		if node != nil && node.key != "" {
+	for i, node := range ht.buckets {
+		if ht.states[i] == SlotOccupied {
 			values = append(values, node.value)
 		}
 	}
@@ -156,15 +415,46 @@ func (ht *HashTable[K, V]) IsEmpty() bool {
 
 // Clear elimina todos los elementos de la tabla de hash.
 func (ht *HashTable[K, V]) Clear() {
-	ht.buckets = make([]*hashTableEntry[K, V], ht.capacity)
+	ht.buckets = make([]hashTableEntry[K, V], ht.capacity)
+	ht.states = make([]SlotState, ht.capacity)
+	if ht.canon != nil {
+		ht.canon = make([]K, ht.capacity)
+	}
 	ht.size = 0
+	ht.deleted = 0
+	ht.modCount++
+}
+
+// SetMaxProbeLength establece la longitud de prueba a partir de la cual la
+// tabla cambia su semilla y reubica todos sus elementos. Si es 0, no se
+// controla la longitud de prueba.
+//
+// La semilla se cambia a lo sumo una vez entre dos redimensionamientos.
+func (ht *HashTable[K, V]) SetMaxProbeLength(n uint) {
+	ht.maxProbeLength = n
+}
+
+// SetMaxCapacity establece la capacidad máxima de la tabla. Si es 0, la tabla
+// no tiene límite.
+//
+// Cuando la tabla alcanza su umbral de carga y no puede redimensionarse sin
+// superar la capacidad máxima, no se pueden agregar claves nuevas (ver
+// TryPut). La capacidad actual no se reduce.
+func (ht *HashTable[K, V]) SetMaxCapacity(n uint) {
+	ht.maxCapacity = n
+}
+
+// Reseeds devuelve la cantidad de veces que la tabla cambió su semilla por
+// detectar secuencias de prueba demasiado largas.
+func (ht *HashTable[K, V]) Reseeds() uint {
+	return ht.reseeds
 }
 
 // String devuelve una representación en cadena de la tabla de hash.
 func (ht *HashTable[K, V]) String() string {
 	result := "{"
-	for _, node := range ht.buckets {
-		if node != nil && node.key != "" {
+	for i, node := range ht.buckets {
+		if ht.states[i] == SlotOccupied {
 			result += fmt.Sprintf("%v: %v", node.key, node.value) + ", "
 		}
 	}
@@ -177,13 +467,50 @@ func (ht *HashTable[K, V]) String() string {
 
 // Funciones privadas //////////////////////////////////////////////////////////
 
+// newHashTable crea una tabla sin funciones de hash ni de igualdad, ajustando
+// la capacidad y el factor de carga.
+func newHashTable[K any, V any](capacity uint, loadFactor float32) *HashTable[K, V] {
+	if capacity == 0 {
+		capacity = 17
+	}
+	if loadFactor <= 0 || loadFactor > 1 {
+		loadFactor = defaultLoadFactor
+	}
+	if !isPrime(capacity) {
+		capacity = nextPrime(capacity)
+	}
+	return &HashTable[K, V]{
+		buckets:        make([]hashTableEntry[K, V], capacity),
+		states:         make([]SlotState, capacity),
+		size:           0,
+		capacity:       capacity,
+		loadFactor:     loadFactor,
+		threshold:      uint(float32(capacity) * loadFactor),
+		seed:           maphash.MakeSeed(),
+		maxProbeLength: DefaultMaxProbeLength,
+	}
+}
+
 // a es una constante utilizada para calcular el hash de un string
 const a float64 = 11.0
 
 // hash calcula el índice del bucket para una clave dada.
 //
-// Se utiliza la técnica de Mulitiplicación Polinómica.
+// Se utiliza la función de hash de la tabla, que depende de su semilla. En las
+// tablas creadas con NewLegacyHashTable, para las claves string se utiliza la
+// técnica de Mulitiplicación Polinómica.
 func (ht *HashTable[K, V]) hash(key K) uint {
+	if ht.legacy {
+		if s, ok := any(key).(string); ok {
+			return polynomialHash(s)
+		}
+	}
+	return uint(ht.hashKey(ht.seed, key))
+}
+
+// polynomialHash calcula el hash de un string con la técnica de
+// Mulitiplicación Polinómica.
+func polynomialHash(key string) uint {
 	l := len(key)
 	var hash uint = 0
 	for i, c := range key {
@@ -195,42 +522,157 @@ func (ht *HashTable[K, V]) hash(key K) uint {
 // getIndex devuelve el índice del bucket para una clave dada y un booleano que
 // indica si la clave existe.
 func (ht *HashTable[K, V]) getIndex(key K) (uint, bool) {
-	if key == "" {
+	if ht.invalid != nil && ht.invalid(key) {
 		return 0, false
 	}
-	for index := ht.hash(key) % ht.capacity; ht.buckets[index] != nil; index = (index + 1) % ht.capacity {
-		if ht.buckets[index].key == key {
+	// Se recorren a lo sumo capacity posiciones, ya que el arreglo puede no
+	// tener posiciones vacías si tiene muchas eliminadas.
+	canonical := ht.canonicalize(key)
+	index := ht.hash(canonical) % ht.capacity
+	for probe := uint(0); probe < ht.capacity && ht.states[index] != SlotEmpty; probe++ {
+		if ht.observer != nil {
+			ht.observer.Probe(index, probe)
+		}
+		if ht.states[index] == SlotOccupied && ht.equal(ht.probeKey(index), canonical) {
 			return index, true
 		}
+		index = (index + 1) % ht.capacity
 	}
 	return 0, false
 }
 
+// canonicalize devuelve la forma canónica de la clave, con la que se calcula
+// su hash y se compara con las claves de la tabla.
+func (ht *HashTable[K, V]) canonicalize(key K) K {
+	if ht.canonical == nil {
+		return key
+	}
+	return ht.canonical(key)
+}
+
+// probeKey devuelve la forma canónica de la clave de la posición dada, que
+// debe estar ocupada.
+func (ht *HashTable[K, V]) probeKey(index uint) K {
+	if ht.canon != nil {
+		return ht.canon[index]
+	}
+	return ht.buckets[index].key
+}
+
+// removeAt elimina el par de la posición dada, que debe estar ocupada.
+func (ht *HashTable[K, V]) removeAt(index uint) {
+	// Se descarta el par para no retener memoria y se marca la posición como
+	// eliminada.
+	ht.buckets[index] = hashTableEntry[K, V]{}
+	if ht.canon != nil {
+		var zeroKey K
+		ht.canon[index] = zeroKey
+	}
+	ht.states[index] = SlotDeleted
+	ht.size--
+	ht.deleted++
+	ht.modCount++
+	if ht.observer != nil {
+		ht.observer.TombstoneCreated(index)
+	}
+}
+
+// makeRoom libera posiciones para agregar una clave. Si la mayoría de las
+// posiciones usadas están eliminadas, reubica los elementos con la misma
+// capacidad, lo que vuelve a dejar posiciones vacías; si no, redimensiona la
+// tabla.
+//
+// - Si la tabla no puede crecer sin superar la capacidad máxima pero tiene
+// posiciones eliminadas, reubica los elementos con la misma capacidad.
+// Si tampoco así queda lugar, devuelve un error que envuelve
+// ErrCapacityExceeded.
+func (ht *HashTable[K, V]) makeRoom() error {
+	if ht.deleted >= ht.size {
+		ht.rehash(ht.capacity)
+		return nil
+	}
+	err := ht.resize()
+	if err != nil && ht.size < ht.threshold {
+		ht.rehash(ht.capacity)
+		return nil
+	}
+	return err
+}
+
 // resize redimensiona la tabla de hash y reubica todos los elementos en la
 // nueva tabla.
 //
 // El nuevo tamaño es el siguiente número primo mayor o igual al doble de la
-// capacidad actual.
-func (ht *HashTable[K, V]) resize() {
+// capacidad actual, o el mayor número primo que no supera la capacidad
+// máxima.
+//
+// - Si la tabla no puede crecer sin superar la capacidad máxima, devuelve un
+// error que envuelve ErrCapacityExceeded.
+func (ht *HashTable[K, V]) resize() error {
 	newCapacity := nextPrime(ht.capacity * 2)
-	newBuckets := make([]*hashTableEntry[K, V], newCapacity)
+	if ht.maxCapacity > 0 && newCapacity > ht.maxCapacity {
+		newCapacity = prevPrime(ht.maxCapacity)
+		if newCapacity <= ht.capacity {
+			return fmt.Errorf("%w: %d", ErrCapacityExceeded, ht.maxCapacity)
+		}
+	}
+	ht.rehash(newCapacity)
+	ht.reseeded = false
+	return nil
+}
+
+// reseed elige una nueva semilla aleatoria y reubica todos los elementos con
+// la misma capacidad.
+func (ht *HashTable[K, V]) reseed() {
+	ht.seed = maphash.MakeSeed()
+	ht.legacy = false
+	ht.reseeds++
+	ht.reseeded = true
+	ht.rehash(ht.capacity)
+}
+
+// rehash reubica todos los elementos en un nuevo arreglo de la capacidad dada,
+// descartando las posiciones eliminadas.
+func (ht *HashTable[K, V]) rehash(newCapacity uint) {
+	var start time.Time
+	if ht.observer != nil {
+		start = time.Now()
+	}
+	oldCapacity := ht.capacity
+	newBuckets := make([]hashTableEntry[K, V], newCapacity)
+	newStates := make([]SlotState, newCapacity)
+	var newCanon []K
+	if ht.canon != nil {
+		newCanon = make([]K, newCapacity)
+	}
 
 	// Reinsertar todos los elementos en el nuevo arreglo, manejando colisiones
-	for _, node := range ht.buckets {
-		if node != nil && node.key != "" {
-			index := ht.hash(node.key) % newCapacity
-			for newBuckets[index] != nil {
+	for i, node := range ht.buckets {
+		if ht.states[i] == SlotOccupied {
+			index := ht.hash(ht.probeKey(uint(i))) % newCapacity
+			for newStates[index] != SlotEmpty {
 				// Resolver colisiones con prueba lineal
 				index = (index + 1) % newCapacity
 			}
 			newBuckets[index] = node
+			newStates[index] = SlotOccupied
+			if newCanon != nil {
+				newCanon[index] = ht.canon[i]
+			}
 		}
 	}
 
 	// Actualizar los atributos de la tabla hash
 	ht.buckets = newBuckets
+	ht.canon = newCanon
+	ht.modCount++
+	ht.states = newStates
+	ht.deleted = 0
 	ht.capacity = newCapacity
 	ht.threshold = uint(float32(newCapacity) * ht.loadFactor)
+	if ht.observer != nil {
+		ht.observer.Resize(oldCapacity, newCapacity, time.Since(start))
+	}
 }
 
 // nextPrime devuelve el siguiente número primo mayor o igual a n.
@@ -245,6 +687,17 @@ func nextPrime(n uint) uint {
 	}
 }
 
+// prevPrime devuelve el mayor número primo menor o igual a n, o 0 si no
+// existe.
+func prevPrime(n uint) uint {
+	for i := n; i >= 2; i-- {
+		if isPrime(i) {
+			return i
+		}
+	}
+	return 0
+}
+
 // isPrime devuelve true si n es un número primo, false en caso contrario.
 func isPrime(n uint) bool {
 	if n <= 1 {
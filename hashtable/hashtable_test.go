@@ -0,0 +1,279 @@
+package hashtable
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collidingKeys devuelve 2^k claves distintas de longitud 2k con el mismo hash
+// polinómico. Se basa en que "aL" y "bA" tienen el mismo hash ('a'*11 + 'L' ==
+// 'b'*11 + 'A'), por lo que cualquier concatenación de esos bloques también.
+func collidingKeys(k int) []string {
+	keys := []string{""}
+	for range k {
+		next := make([]string, 0, 2*len(keys))
+		for _, key := range keys {
+			next = append(next, key+"aL", key+"bA")
+		}
+		keys = next
+	}
+	return keys
+}
+
+// maxProbe devuelve la longitud de prueba más larga entre las claves de la
+// tabla, es decir, la mayor distancia entre el bucket inicial de una clave y
+// el bucket que ocupa.
+func maxProbe[K any, V any](ht *HashTable[K, V]) uint {
+	var result uint
+	for index, node := range ht.buckets {
+		if ht.states[index] == SlotOccupied {
+			home := ht.hash(node.key) % ht.capacity
+			result = max(result, (uint(index)+ht.capacity-home)%ht.capacity)
+		}
+	}
+	return result
+}
+
+func TestNewHashTable(t *testing.T) {
+	ht := NewHashTable[string, int](10, 0.5)
+
+	assert.Equal(t, uint(0), ht.Size())
+	assert.True(t, ht.IsEmpty())
+	assert.Equal(t, uint(11), ht.capacity)
+	assert.Equal(t, "{}", ht.String())
+}
+
+func TestHashTablePutGet(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+
+	assert.True(t, ht.Put("uno", 1))
+	assert.True(t, ht.Put("dos", 2))
+	assert.True(t, ht.Put("uno", 10))
+
+	v, ok := ht.Get("uno")
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	_, ok = ht.Get("tres")
+	assert.False(t, ok)
+	assert.Equal(t, uint(2), ht.Size())
+}
+
+func TestHashTableRemove(t *testing.T) {
+	ht := NewHashTable[int, string](0, 0)
+	ht.Put(1, "uno")
+	ht.Put(2, "dos")
+
+	assert.True(t, ht.Remove(1))
+	assert.False(t, ht.Remove(1))
+	_, ok := ht.Get(1)
+	assert.False(t, ok)
+	assert.Equal(t, []int{2}, ht.Keys())
+	assert.Equal(t, []string{"dos"}, ht.Values())
+}
+
+func TestHashTableResize(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	for i := range 1000 {
+		ht.Put(fmt.Sprintf("clave-%d", i), i)
+	}
+
+	assert.Equal(t, uint(1000), ht.Size())
+	assert.Greater(t, ht.capacity, uint(1000))
+	for i := range 1000 {
+		v, ok := ht.Get(fmt.Sprintf("clave-%d", i))
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestHashTableClear(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	ht.Put("uno", 1)
+
+	ht.Clear()
+	assert.True(t, ht.IsEmpty())
+	_, ok := ht.Get("uno")
+	assert.False(t, ok)
+}
+
+func TestHashTableString(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	ht.Put("uno", 1)
+
+	assert.Equal(t, "{uno: 1}", ht.String())
+}
+
+func TestCollidingKeys(t *testing.T) {
+	keys := collidingKeys(8)
+
+	require.Len(t, keys, 256)
+	for _, key := range keys {
+		assert.Equal(t, polynomialHash(keys[0]), polynomialHash(key))
+	}
+}
+
+func TestHashTableSemillaPorInstancia(t *testing.T) {
+	a := NewHashTable[string, int](0, 0)
+	b := NewHashTable[string, int](0, 0)
+
+	assert.NotEqual(t, a.hash("hola"), b.hash("hola"))
+}
+
+func TestHashTableSemillaResisteColisiones(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	for i, key := range collidingKeys(8) {
+		ht.Put(key, i)
+	}
+
+	assert.Less(t, maxProbe(ht), uint(DefaultMaxProbeLength))
+	assert.Equal(t, uint(0), ht.Reseeds())
+}
+
+func TestLegacyHashTableSinControl(t *testing.T) {
+	ht := NewLegacyHashTable[string, int](0, 0)
+	ht.SetMaxProbeLength(0)
+	keys := collidingKeys(8)
+	for i, key := range keys {
+		ht.Put(key, i)
+	}
+
+	// Todas las claves comparten el bucket inicial: la última queda a una
+	// distancia igual a la cantidad de claves.
+	assert.Equal(t, uint(len(keys)-1), maxProbe(ht))
+	assert.Equal(t, uint(0), ht.Reseeds())
+}
+
+func TestLegacyHashTableCambiaSemilla(t *testing.T) {
+	ht := NewLegacyHashTable[string, int](0, 0)
+	keys := collidingKeys(9)
+	for i, key := range keys {
+		ht.Put(key, i)
+	}
+
+	assert.GreaterOrEqual(t, ht.Reseeds(), uint(1))
+	assert.False(t, ht.legacy)
+	assert.Less(t, maxProbe(ht), uint(DefaultMaxProbeLength))
+	assert.Equal(t, uint(len(keys)), ht.Size())
+	for i, key := range keys {
+		v, ok := ht.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestHashTableCambiaSemillaConservaElementos(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	for i := range 100 {
+		ht.Put(fmt.Sprintf("clave-%d", i), i)
+	}
+	ht.Remove("clave-0")
+	seed := ht.seed
+
+	ht.reseed()
+	assert.NotEqual(t, seed, ht.seed)
+	assert.Equal(t, uint(99), ht.Size())
+	for i := 1; i < 100; i++ {
+		v, ok := ht.Get(fmt.Sprintf("clave-%d", i))
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestHashTableGetSinPosicionesVacias(t *testing.T) {
+	ht := NewHashTable[int, int](5, 0.75)
+	for i := 1; i <= 50; i++ {
+		ht.Put(i, i)
+		ht.Remove(i)
+	}
+
+	// Las posiciones eliminadas cuentan para el umbral, por lo que la tabla
+	// se reubica antes de quedarse sin posiciones vacías.
+	assert.Contains(t, ht.states, SlotEmpty)
+	assert.Equal(t, uint(5), ht.Capacity())
+	_, ok := ht.Get(1000)
+	assert.False(t, ok)
+}
+
+func TestHashTableEliminadasNoAgotanLasPosicionesVacias(t *testing.T) {
+	ht := NewHashTable[int, int](0, 0)
+	for i := range 1000 {
+		ht.Put(i, i)
+	}
+	capacity := ht.Capacity()
+
+	// Con una cantidad estable de claves, las inserciones y eliminaciones no
+	// dejan sin posiciones vacías, y la tabla crece a lo sumo una vez.
+	for i := 1000; i < 200_000; i++ {
+		ht.Put(i, i)
+		ht.Remove(i - 1000)
+	}
+	assert.LessOrEqual(t, ht.Capacity(), nextPrime(2*capacity))
+	assert.Equal(t, uint(1000), ht.Size())
+	assert.LessOrEqual(t, ht.size+ht.deleted, ht.threshold)
+	empty := uint(0)
+	for _, state := range ht.states {
+		if state == SlotEmpty {
+			empty++
+		}
+	}
+	assert.GreaterOrEqual(t, empty, ht.capacity-ht.threshold)
+
+	observer := &countingObserver{}
+	ht.SetObserver(observer)
+	_, ok := ht.Get(-1)
+	assert.False(t, ok)
+	assert.Less(t, observer.probes, int(ht.capacity/4), "la búsqueda fallida no recorre toda la tabla")
+}
+
+func TestHashTableClaveVacia(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+
+	assert.True(t, ht.Put("", 1))
+	assert.True(t, ht.Put("uno", 2))
+	v, ok := ht.Get("")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, uint(2), ht.Size())
+	assert.ElementsMatch(t, []string{"", "uno"}, ht.Keys())
+	assert.Contains(t, ht.String(), ": 1")
+
+	assert.True(t, ht.Remove(""))
+	assert.False(t, ht.Remove(""))
+	_, ok = ht.Get("")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"uno"}, ht.Keys())
+	assert.Equal(t, "{uno: 2}", ht.String())
+}
+
+func TestHashTableClaveCero(t *testing.T) {
+	ht := NewHashTable[int, string](0, 0)
+	ht.Put(0, "cero")
+
+	v, ok := ht.Get(0)
+	assert.True(t, ok)
+	assert.Equal(t, "cero", v)
+}
+
+func TestHashTablePutNoDuplicaTrasEliminar(t *testing.T) {
+	// Todas las claves comparten la posición inicial, por lo que "bAaL" queda
+	// detrás de "aLaL" en la secuencia de prueba.
+	ht := NewLegacyHashTable[string, int](0, 0)
+	keys := collidingKeys(2)
+	for i, key := range keys {
+		ht.Put(key, i)
+	}
+
+	ht.Remove(keys[0])
+	ht.Put(keys[2], 20)
+	assert.Equal(t, uint(3), ht.Size())
+	assert.Len(t, ht.Keys(), 3)
+	v, _ := ht.Get(keys[2])
+	assert.Equal(t, 20, v)
+
+	ht.Remove(keys[2])
+	_, ok := ht.Get(keys[2])
+	assert.False(t, ok)
+}
@@ -0,0 +1,69 @@
+package hashtable
+
+// SlotState indica el estado de una posición del arreglo de la tabla.
+type SlotState uint8
+
+const (
+	// SlotEmpty indica una posición que nunca fue ocupada.
+	SlotEmpty SlotState = iota
+	// SlotOccupied indica una posición que contiene un par clave-valor.
+	SlotOccupied
+	// SlotDeleted indica una posición cuyo par clave-valor fue eliminado.
+	SlotDeleted
+)
+
+// String devuelve el nombre del estado.
+func (s SlotState) String() string {
+	switch s {
+	case SlotEmpty:
+		return "empty"
+	case SlotOccupied:
+		return "occupied"
+	case SlotDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// Slot describe una posición del arreglo de la tabla, para inspeccionar o
+// visualizar cómo se distribuyen los elementos.
+type Slot[K any, V any] struct {
+	// Index es la posición en el arreglo.
+	Index uint
+	// State es el estado de la posición.
+	State SlotState
+	// Key y Value son el par almacenado, si la posición está ocupada.
+	Key   K
+	Value V
+	// Home es la posición que le corresponde a la clave según su hash. Si es
+	// distinta de Index, la clave fue desplazada por colisiones y se llega a
+	// ella recorriendo las posiciones de Home a Index.
+	Home uint
+}
+
+// Displacement devuelve la cantidad de posiciones que hay que recorrer desde
+// Home hasta Index, teniendo en cuenta que la prueba lineal vuelve al inicio
+// del arreglo al llegar al final.
+func (s Slot[K, V]) Displacement(capacity uint) uint {
+	return (s.Index + capacity - s.Home) % capacity
+}
+
+// Slots devuelve el estado de cada posición del arreglo de la tabla, en orden.
+func (ht *HashTable[K, V]) Slots() []Slot[K, V] {
+	slots := make([]Slot[K, V], ht.capacity)
+	for i, node := range ht.buckets {
+		slot := Slot[K, V]{Index: uint(i), State: ht.states[i], Home: uint(i)}
+		if slot.State == SlotOccupied {
+			slot.Key = node.key
+			slot.Value = node.value
+			slot.Home = ht.hash(ht.probeKey(uint(i))) % ht.capacity
+		}
+		slots[i] = slot
+	}
+	return slots
+}
+
+// Capacity devuelve la cantidad de posiciones del arreglo de la tabla.
+func (ht *HashTable[K, V]) Capacity() uint {
+	return ht.capacity
+}
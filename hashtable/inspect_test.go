@@ -0,0 +1,42 @@
+package hashtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlots(t *testing.T) {
+	ht := NewLegacyHashTable[string, int](5, 1)
+	ht.Put("aL", 1)
+	ht.Put("bA", 2)
+	ht.Put("c", 3)
+	ht.Remove("c")
+
+	slots := ht.Slots()
+	assert.Len(t, slots, int(ht.Capacity()))
+
+	home := polynomialHash("aL") % ht.Capacity()
+	first, second := slots[home], slots[(home+1)%ht.Capacity()]
+	assert.Equal(t, SlotOccupied, first.State)
+	assert.Equal(t, "aL", first.Key)
+	assert.Equal(t, 1, first.Value)
+	assert.Equal(t, uint(0), first.Displacement(ht.Capacity()))
+	assert.Equal(t, SlotOccupied, second.State)
+	assert.Equal(t, "bA", second.Key)
+	assert.Equal(t, home, second.Home)
+	assert.Equal(t, uint(1), second.Displacement(ht.Capacity()))
+
+	count := map[SlotState]int{}
+	for _, s := range slots {
+		count[s.State]++
+	}
+	assert.Equal(t, map[SlotState]int{SlotOccupied: 2, SlotDeleted: 1, SlotEmpty: 2}, count)
+}
+
+func TestSlotStateString(t *testing.T) {
+	assert.Equal(t, "empty", SlotEmpty.String())
+	assert.Equal(t, "occupied", SlotOccupied.String())
+	assert.Equal(t, "deleted", SlotDeleted.String())
+	assert.Equal(t, "unknown", SlotState(9).String())
+}
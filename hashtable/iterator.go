@@ -0,0 +1,131 @@
+package hashtable
+
+// Iterator recorre los pares de una HashTable.
+//
+// El iterador es fail-fast: si la tabla se modifica estructuralmente durante
+// la iteración (se agregan o eliminan claves, o se reubican sus elementos)
+// sin utilizar el iterador, Next devuelve false y Err devuelve
+// ErrConcurrentModification, o bien entra en pánico si así se configuró con
+// SetPanicOnModification. Actualizar el valor de una clave existente no es una
+// modificación estructural.
+//
+// Uso:
+//
+//	it := ht.Iterator()
+//	for it.Next() {
+//		if it.Value() < 0 {
+//			it.Remove() // Elimina el par actual sin invalidar el iterador.
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		// La tabla se modificó durante la iteración.
+//	}
+type Iterator[K any, V any] struct {
+	// ht es la tabla que se recorre.
+	ht *HashTable[K, V]
+	// index es la posición del elemento actual, o la posición anterior a la
+	// primera si todavía no se llamó a Next.
+	index int
+	// current indica si el iterador está posicionado en un elemento.
+	current bool
+	// expected es el contador de modificaciones esperado en la tabla.
+	expected uint
+	// panics indica si se entra en pánico al detectar una modificación.
+	panics bool
+	// err es el error que detuvo la iteración.
+	err error
+}
+
+// Iterator devuelve un iterador posicionado antes del primer par de la tabla.
+func (ht *HashTable[K, V]) Iterator() *Iterator[K, V] {
+	return &Iterator[K, V]{ht: ht, index: -1, expected: ht.modCount}
+}
+
+// SetPanicOnModification establece si el iterador entra en pánico al detectar
+// una modificación de la tabla durante la iteración, en lugar de detenerse y
+// devolver el error en Err.
+func (it *Iterator[K, V]) SetPanicOnModification(panics bool) {
+	it.panics = panics
+}
+
+// Next avanza al siguiente par de la tabla.
+//
+// Devuelve false si no hay más pares o si la tabla se modificó durante la
+// iteración (ver Err).
+func (it *Iterator[K, V]) Next() bool {
+	it.current = false
+	if it.err != nil || !it.check() {
+		return false
+	}
+	for it.index++; it.index < len(it.ht.states); it.index++ {
+		if it.ht.states[it.index] == SlotOccupied {
+			it.current = true
+			return true
+		}
+	}
+	return false
+}
+
+// Key devuelve la clave del par actual.
+//
+// - Si el iterador no está posicionado en un par, devuelve el valor nulo.
+func (it *Iterator[K, V]) Key() K {
+	if !it.current {
+		var zeroKey K
+		return zeroKey
+	}
+	return it.ht.buckets[it.index].key
+}
+
+// Value devuelve el valor del par actual.
+//
+// - Si el iterador no está posicionado en un par, devuelve el valor nulo.
+func (it *Iterator[K, V]) Value() V {
+	if !it.current {
+		var zeroValue V
+		return zeroValue
+	}
+	return it.ht.buckets[it.index].value
+}
+
+// Remove elimina de la tabla el par actual, sin invalidar el iterador. La
+// siguiente llamada a Next avanza al par que sigue al eliminado.
+//
+// - Si el iterador no está posicionado en un par, devuelve ErrNoCurrent.
+//
+// - Si la tabla se modificó durante la iteración, devuelve
+// ErrConcurrentModification (o entra en pánico, ver SetPanicOnModification).
+func (it *Iterator[K, V]) Remove() error {
+	if it.err != nil || !it.check() {
+		return it.err
+	}
+	if !it.current {
+		return ErrNoCurrent
+	}
+	// Eliminar deja una marca en la posición, por lo que el resto de los
+	// pares no cambia de lugar.
+	it.ht.removeAt(uint(it.index))
+	it.expected = it.ht.modCount
+	it.current = false
+	return nil
+}
+
+// Err devuelve el error que detuvo la iteración, o nil si la iteración
+// terminó normalmente o no terminó.
+func (it *Iterator[K, V]) Err() error {
+	return it.err
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// check verifica que la tabla no se haya modificado fuera del iterador.
+func (it *Iterator[K, V]) check() bool {
+	if it.ht.modCount == it.expected {
+		return true
+	}
+	it.err = ErrConcurrentModification
+	if it.panics {
+		panic(it.err)
+	}
+	return false
+}
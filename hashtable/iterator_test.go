@@ -0,0 +1,97 @@
+package hashtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator(t *testing.T) {
+	ht := FromMap(map[string]int{"uno": 1, "dos": 2, "tres": 3})
+
+	got := make(map[string]int)
+	it := ht.Iterator()
+	for it.Next() {
+		got[it.Key()] = it.Value()
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, map[string]int{"uno": 1, "dos": 2, "tres": 3}, got)
+	assert.False(t, it.Next())
+	assert.Equal(t, "", it.Key())
+	assert.Equal(t, 0, it.Value())
+}
+
+func TestIteratorVacio(t *testing.T) {
+	it := NewHashTable[string, int](0, 0).Iterator()
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.ErrorIs(t, it.Remove(), ErrNoCurrent)
+}
+
+func TestIteratorActualizarValorNoEsModificacion(t *testing.T) {
+	ht := FromMap(map[string]int{"uno": 1, "dos": 2})
+
+	it := ht.Iterator()
+	for it.Next() {
+		ht.Put(it.Key(), it.Value()*10)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, map[string]int{"uno": 10, "dos": 20}, ToMap(ht))
+}
+
+func TestIteratorDetectaModificacion(t *testing.T) {
+	modifications := map[string]func(ht *HashTable[int, int]){
+		"Put":     func(ht *HashTable[int, int]) { ht.Put(100, 100) },
+		"Remove":  func(ht *HashTable[int, int]) { ht.Remove(2) },
+		"Clear":   func(ht *HashTable[int, int]) { ht.Clear() },
+		"Reserve": func(ht *HashTable[int, int]) { ht.Reserve(1000) },
+	}
+	for name, modify := range modifications {
+		t.Run(name, func(t *testing.T) {
+			ht := FromMap(map[int]int{1: 1, 2: 2, 3: 3})
+			it := ht.Iterator()
+			require.True(t, it.Next())
+
+			modify(ht)
+			assert.False(t, it.Next())
+			assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+			assert.ErrorIs(t, it.Remove(), ErrConcurrentModification)
+		})
+	}
+}
+
+func TestIteratorPanico(t *testing.T) {
+	ht := FromMap(map[int]int{1: 1, 2: 2})
+	it := ht.Iterator()
+	it.SetPanicOnModification(true)
+	require.True(t, it.Next())
+
+	ht.Put(3, 3)
+	assert.PanicsWithError(t, ErrConcurrentModification.Error(), func() { it.Next() })
+}
+
+func TestIteratorRemove(t *testing.T) {
+	ht := NewHashTable[int, int](0, 0)
+	for i := range 100 {
+		ht.Put(i, i)
+	}
+
+	visited := 0
+	it := ht.Iterator()
+	for it.Next() {
+		visited++
+		if it.Key()%2 == 0 {
+			require.NoError(t, it.Remove())
+			assert.ErrorIs(t, it.Remove(), ErrNoCurrent)
+		}
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, 100, visited)
+	assert.Equal(t, uint(50), ht.Size())
+	for i := range 100 {
+		_, ok := ht.Get(i)
+		assert.Equal(t, i%2 == 1, ok)
+	}
+}
@@ -0,0 +1,275 @@
+package hashtable
+
+import (
+	"fmt"
+	"hash/maphash"
+)
+
+// linearInitialBuckets es la cantidad inicial de buckets de una
+// LinearHashTable.
+const linearInitialBuckets = 4
+
+// LinearHashTable es una tabla de hash dinámica que utiliza hashing lineal
+// (Litwin, 1980).
+//
+// Cada bucket almacena hasta bucketSize pares; si un bucket se llena, sus
+// pares adicionales quedan en páginas de desborde encadenadas a él, también de
+// bucketSize pares cada una. Las divisiones no dependen de qué bucket desborda:
+// cuando el factor de carga supera el máximo, se divide un único bucket, el
+// indicado por el puntero de división, y sus pares se reparten entre él y un
+// nuevo bucket al final. El puntero avanza de a un bucket, y al terminar una
+// ronda la cantidad de buckets se duplicó. Así, la tabla crece de a un bucket
+// por vez, sin reubicar nunca todos sus elementos.
+//
+// La posición de una clave se calcula como hash mod (n·2^nivel); si el
+// resultado es menor que el puntero de división, ese bucket ya se dividió en
+// la ronda actual y se usa hash mod (n·2^(nivel+1)).
+//
+// La tabla no se achica al eliminar elementos.
+type LinearHashTable[K comparable, V any] struct {
+	// buckets son los buckets de la tabla. Cada uno es la primera página de
+	// su cadena de desborde.
+	buckets []*bucket[K, V]
+	// size es el número de elementos en la tabla.
+	size uint
+	// bucketSize es la cantidad de elementos por página.
+	bucketSize uint
+	// loadFactor es el factor de carga a partir del cual se divide un bucket.
+	loadFactor float32
+	// level es la cantidad de rondas de división completas.
+	level uint
+	// split es el puntero de división: el próximo bucket a dividir.
+	split uint
+	// seed es la semilla utilizada para calcular el hash de las claves.
+	seed maphash.Seed
+}
+
+// NewLinearHashTable crea una nueva tabla de hashing lineal vacía.
+//
+// - Si bucketSize es igual a 0, se establece en DefaultBucketSize.
+//
+// - Si el factor de carga es menor o igual a 0, se establece en 0.75. Puede
+// ser mayor que 1, en cuyo caso los buckets desbordan antes de dividirse.
+func NewLinearHashTable[K comparable, V any](bucketSize uint, loadFactor float32) *LinearHashTable[K, V] {
+	if bucketSize == 0 {
+		bucketSize = DefaultBucketSize
+	}
+	if loadFactor <= 0 {
+		loadFactor = 0.75
+	}
+	lh := &LinearHashTable[K, V]{
+		bucketSize: bucketSize,
+		loadFactor: loadFactor,
+		seed:       maphash.MakeSeed(),
+	}
+	lh.Clear()
+	return lh
+}
+
+// Put agrega un nuevo par clave-valor a la tabla. Si la clave ya existe,
+// actualiza el valor asociado a la clave.
+//
+// Devuelve true si se agregó o actualizó el elemento, false si la clave no es
+// válida.
+//
+// - Si el factor de carga supera el máximo, se divide un bucket.
+func (lh *LinearHashTable[K, V]) Put(key K, value V) bool {
+	return lh.TryPut(key, value) == nil
+}
+
+// TryPut es como Put, pero devuelve un error que envuelve ErrInvalidKey si la
+// clave no es igual a sí misma (NaN).
+func (lh *LinearHashTable[K, V]) TryPut(key K, value V) error {
+	if key != key {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	b := lh.buckets[lh.address(lh.hash(key))]
+	if page, i := b.lookup(key); i >= 0 {
+		page.entries[i].value = value
+		return nil
+	}
+	b.insert(hashTableEntry[K, V]{key: key, value: value}, lh.bucketSize)
+	lh.size++
+	if float32(lh.size) > lh.loadFactor*float32(uint(len(lh.buckets))*lh.bucketSize) {
+		lh.splitNext()
+	}
+	return nil
+}
+
+// Get devuelve el valor asociado a la clave dada y true para indicar que
+// encontró la clave buscada.
+//
+// - Si la clave no existe, devuelve false y un valor nulo.
+func (lh *LinearHashTable[K, V]) Get(key K) (V, bool) {
+	b := lh.buckets[lh.address(lh.hash(key))]
+	if page, i := b.lookup(key); i >= 0 {
+		return page.entries[i].value, true
+	}
+	var zeroValue V
+	return zeroValue, false
+}
+
+// TryGet es como Get, pero devuelve un error que envuelve ErrNotFound si no
+// encuentra la clave, o ErrInvalidKey si la clave no es válida.
+func (lh *LinearHashTable[K, V]) TryGet(key K) (V, error) {
+	var zeroValue V
+	if key != key {
+		return zeroValue, fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	value, exists := lh.Get(key)
+	if !exists {
+		return zeroValue, fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	return value, nil
+}
+
+// MustGet es como Get, pero entra en pánico si no encuentra la clave.
+func (lh *LinearHashTable[K, V]) MustGet(key K) V {
+	value, err := lh.TryGet(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Remove elimina el par clave-valor asociado a la clave dada.
+//
+// Devuelve true si se eliminó el elemento, false si la clave no existe.
+//
+// - El hueco se completa con el último par de la cadena de desborde, y la
+// última página se libera si queda vacía.
+func (lh *LinearHashTable[K, V]) Remove(key K) bool {
+	b := lh.buckets[lh.address(lh.hash(key))]
+	page, i := b.lookup(key)
+	if i < 0 {
+		return false
+	}
+	b.delete(page, i)
+	lh.size--
+	return true
+}
+
+// TryRemove es como Remove, pero devuelve un error que envuelve ErrNotFound si
+// no encuentra la clave, o ErrInvalidKey si la clave no es válida.
+func (lh *LinearHashTable[K, V]) TryRemove(key K) error {
+	if key != key {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, key)
+	}
+	if !lh.Remove(key) {
+		return fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+	return nil
+}
+
+// Keys devuelve una lista de todas las claves en la tabla.
+func (lh *LinearHashTable[K, V]) Keys() []K {
+	keys := make([]K, 0, lh.size)
+	for _, b := range lh.buckets {
+		for page := b; page != nil; page = page.overflow {
+			for _, entry := range page.entries {
+				keys = append(keys, entry.key)
+			}
+		}
+	}
+	return keys
+}
+
+// Values devuelve una lista de todos los valores en la tabla.
+func (lh *LinearHashTable[K, V]) Values() []V {
+	values := make([]V, 0, lh.size)
+	for _, b := range lh.buckets {
+		for page := b; page != nil; page = page.overflow {
+			for _, entry := range page.entries {
+				values = append(values, entry.value)
+			}
+		}
+	}
+	return values
+}
+
+// Size devuelve el número de elementos en la tabla.
+func (lh *LinearHashTable[K, V]) Size() uint {
+	return lh.size
+}
+
+// IsEmpty devuelve true si la tabla está vacía, false en caso contrario.
+func (lh *LinearHashTable[K, V]) IsEmpty() bool {
+	return lh.size == 0
+}
+
+// Clear elimina todos los elementos de la tabla y vuelve a la cantidad
+// inicial de buckets.
+func (lh *LinearHashTable[K, V]) Clear() {
+	lh.buckets = make([]*bucket[K, V], linearInitialBuckets)
+	for i := range lh.buckets {
+		lh.buckets[i] = &bucket[K, V]{}
+	}
+	lh.size = 0
+	lh.level = 0
+	lh.split = 0
+}
+
+// Buckets devuelve la cantidad de buckets de la tabla, sin contar las páginas
+// de desborde.
+func (lh *LinearHashTable[K, V]) Buckets() uint {
+	return uint(len(lh.buckets))
+}
+
+// OverflowPages devuelve la cantidad de páginas de desborde de la tabla.
+func (lh *LinearHashTable[K, V]) OverflowPages() uint {
+	var pages uint
+	for _, b := range lh.buckets {
+		pages += b.overflowPages()
+	}
+	return pages
+}
+
+// Level devuelve la cantidad de rondas de división completas.
+func (lh *LinearHashTable[K, V]) Level() uint {
+	return lh.level
+}
+
+// SplitPointer devuelve el próximo bucket a dividir.
+func (lh *LinearHashTable[K, V]) SplitPointer() uint {
+	return lh.split
+}
+
+// String devuelve una representación en cadena de la tabla.
+func (lh *LinearHashTable[K, V]) String() string {
+	return formatEntries(lh.buckets)
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// hash calcula el hash de una clave.
+func (lh *LinearHashTable[K, V]) hash(key K) uint64 {
+	return maphash.Comparable(lh.seed, key)
+}
+
+// address devuelve el bucket que le corresponde a un hash.
+func (lh *LinearHashTable[K, V]) address(h uint64) uint {
+	roundSize := uint64(linearInitialBuckets) << lh.level
+	index := h % roundSize
+	if index < uint64(lh.split) {
+		// El bucket ya se dividió en esta ronda.
+		index = h % (2 * roundSize)
+	}
+	return uint(index)
+}
+
+// splitNext divide el bucket indicado por el puntero de división, repartiendo
+// sus pares entre él y un nuevo bucket al final, y avanza el puntero.
+func (lh *LinearHashTable[K, V]) splitNext() {
+	old := lh.buckets[lh.split]
+	lh.buckets = append(lh.buckets, &bucket[K, V]{})
+	lh.split++
+	roundSize := uint(linearInitialBuckets) << lh.level
+	if lh.split == roundSize {
+		lh.level++
+		lh.split = 0
+	}
+
+	for _, entry := range old.drain() {
+		lh.buckets[lh.address(lh.hash(entry.key))].insert(entry, lh.bucketSize)
+	}
+}
@@ -0,0 +1,116 @@
+package hashtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearHashTableCreceDeAUnBucket(t *testing.T) {
+	lh := NewLinearHashTable[int, int](2, 0.75)
+	assert.Equal(t, uint(linearInitialBuckets), lh.Buckets())
+
+	buckets := lh.Buckets()
+	for i := range 500 {
+		lh.Put(i, i)
+		assert.LessOrEqual(t, lh.Buckets(), buckets+1, "se divide a lo sumo un bucket por operación")
+		buckets = lh.Buckets()
+		assert.LessOrEqual(t, float32(lh.Size()), 0.75*float32(2*lh.Buckets()))
+	}
+	for i := range 500 {
+		assert.Equal(t, i, lh.MustGet(i))
+	}
+}
+
+func TestLinearHashTablePunteroDeDivision(t *testing.T) {
+	lh := NewLinearHashTable[int, int](1, 1)
+
+	// Con buckets de un elemento y factor de carga 1, cada elemento a partir
+	// del cuarto divide un bucket.
+	for i := range 4 {
+		lh.Put(i, i)
+	}
+	assert.Equal(t, uint(0), lh.Level())
+	assert.Equal(t, uint(0), lh.SplitPointer())
+
+	lh.Put(4, 4)
+	assert.Equal(t, uint(5), lh.Buckets())
+	assert.Equal(t, uint(1), lh.SplitPointer())
+
+	for i := 5; i < 8; i++ {
+		lh.Put(i, i)
+	}
+	// Al dividir los cuatro buckets iniciales termina la ronda.
+	assert.Equal(t, uint(8), lh.Buckets())
+	assert.Equal(t, uint(1), lh.Level())
+	assert.Equal(t, uint(0), lh.SplitPointer())
+}
+
+func TestLinearHashTableDireccion(t *testing.T) {
+	lh := NewLinearHashTable[int, int](2, 0.75)
+	for i := range 300 {
+		lh.Put(i, i)
+	}
+
+	// Cada clave está en la cadena del bucket que indica su dirección.
+	for index, b := range lh.buckets {
+		for page := b; page != nil; page = page.overflow {
+			for _, entry := range page.entries {
+				assert.Equal(t, uint(index), lh.address(lh.hash(entry.key)))
+			}
+		}
+	}
+}
+
+// checkPages verifica que ninguna página supere bucketSize pares y que las
+// cadenas de desborde no tengan páginas vacías.
+func checkPages[K comparable, V any](t *testing.T, lh *LinearHashTable[K, V]) {
+	t.Helper()
+	for index, b := range lh.buckets {
+		for page := b; page != nil; page = page.overflow {
+			assert.LessOrEqual(t, uint(len(page.entries)), lh.bucketSize, "bucket %d", index)
+			if page != b {
+				assert.NotEmpty(t, page.entries, "bucket %d", index)
+			}
+		}
+	}
+}
+
+func TestLinearHashTablePaginasDeDesborde(t *testing.T) {
+	// Con factor de carga 3, cada bucket llega a tener en promedio tres
+	// páginas antes de que se divida alguno.
+	lh := NewLinearHashTable[int, int](2, 3)
+	for i := range 500 {
+		lh.Put(i, i)
+	}
+	checkPages(t, lh)
+	assert.Positive(t, lh.OverflowPages())
+	assert.Len(t, lh.Keys(), 500)
+
+	for i := 0; i < 500; i += 2 {
+		assert.True(t, lh.Remove(i))
+	}
+	checkPages(t, lh)
+	assert.Equal(t, uint(250), lh.Size())
+	for i := range 500 {
+		_, ok := lh.Get(i)
+		assert.Equal(t, i%2 == 1, ok, "clave %d", i)
+	}
+
+	for i := 1; i < 500; i += 2 {
+		assert.True(t, lh.Remove(i))
+	}
+	assert.Zero(t, lh.OverflowPages())
+}
+
+func TestLinearHashTableClear(t *testing.T) {
+	lh := NewLinearHashTable[int, int](0, 0)
+	for i := range 100 {
+		lh.Put(i, i)
+	}
+
+	lh.Clear()
+	assert.True(t, lh.IsEmpty())
+	assert.Equal(t, uint(linearInitialBuckets), lh.Buckets())
+	assert.Equal(t, uint(0), lh.Level())
+}
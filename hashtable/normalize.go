@@ -0,0 +1,97 @@
+package hashtable
+
+import (
+	"hash/maphash"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization indica cómo se normalizan las claves string antes de
+// compararlas. Los modos pueden combinarse con el operador |, por ejemplo
+// FoldCase | IgnoreAccents.
+type Normalization uint8
+
+const (
+	// NFC compara las claves en la forma de normalización Unicode NFC, de modo
+	// que "ó" escrita como un único carácter y como "o" seguida del acento
+	// combinante son la misma clave.
+	NFC Normalization = 1 << iota
+	// NFKC compara las claves en la forma de normalización Unicode NFKC, que
+	// además unifica caracteres de compatibilidad, como "ﬁ" y "fi" o "²" y
+	// "2".
+	NFKC
+	// FoldCase compara las claves sin distinguir mayúsculas de minúsculas.
+	FoldCase
+	// IgnoreAccents compara las claves sin tener en cuenta los acentos y demás
+	// marcas diacríticas, de modo que "canción" y "cancion" son la misma
+	// clave. La tilde de la ñ se conserva, porque en español es otra letra:
+	// "año" y "ano" son claves distintas.
+	IgnoreAccents
+)
+
+// Normalize devuelve la forma normalizada de s según el modo.
+//
+// - Si el modo es 0, devuelve s sin cambios.
+//
+// - FoldCase e IgnoreAccents implican NFC, salvo que se indique NFKC.
+func (n Normalization) Normalize(s string) string {
+	if n == 0 {
+		return s
+	}
+	form := norm.NFC
+	if n&NFKC != 0 {
+		form = norm.NFKC
+	}
+	if n&IgnoreAccents != 0 {
+		s = stripAccents(s)
+	}
+	if n&FoldCase != 0 {
+		s = cases.Fold().String(s)
+	}
+	return form.String(s)
+}
+
+// NewNormalizedHashTable crea una nueva tabla de hash cerrada con claves
+// string que se comparan luego de normalizarlas según el modo dado.
+//
+// La tabla conserva la clave tal como se agregó por primera vez: si se agrega
+// "Canción" y luego "CANCIÓN" con FoldCase, solo se actualiza el valor y Keys
+// devuelve "Canción".
+//
+// Cada clave se normaliza una única vez por operación: la tabla guarda la
+// forma normalizada de cada clave junto a la original, y durante la prueba
+// lineal solo compara formas normalizadas con ==.
+//
+// La capacidad y el factor de carga se ajustan igual que en NewHashTable.
+//
+// Uso:
+//
+//	ht := hashtable.NewNormalizedHashTable[string](0, 0, hashtable.FoldCase|hashtable.IgnoreAccents)
+func NewNormalizedHashTable[V any](capacity uint, loadFactor float32, mode Normalization) *HashTable[string, V] {
+	ht := newHashTable[string, V](capacity, loadFactor)
+	ht.hashKey = maphash.String
+	ht.equal = func(a, b string) bool { return a == b }
+	ht.canonical = mode.Normalize
+	ht.canon = make([]string, ht.capacity)
+	return ht
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// stripAccents quita las marcas diacríticas de s, salvo la tilde de la ñ. El
+// resultado queda descompuesto, por lo que debe recomponerse.
+func stripAccents(s string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) && (r != '\u0303' || (prev != 'n' && prev != 'N')) {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+package hashtable
+
+import (
+	"fmt"
+	"hash/maphash"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cancionNFC = "Canción"  // "ó" como un único carácter.
+	cancionNFD = "Canción" // "o" seguida del acento combinante.
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		mode     Normalization
+		input    string
+		expected string
+	}{
+		{0, cancionNFD, cancionNFD},
+		{NFC, cancionNFD, cancionNFC},
+		{NFKC, "ﬁn²", "fin2"},
+		{NFC, "ﬁn²", "ﬁn²"},
+		{FoldCase, "CANCIÓN", "canción"},
+		{FoldCase, "Straße", "strasse"},
+		{IgnoreAccents, cancionNFD, "Cancion"},
+		{IgnoreAccents, "Pingüino", "Pinguino"},
+		{IgnoreAccents, "año", "año"},
+		{IgnoreAccents, "Ñandú", "Ñandu"},
+		{IgnoreAccents, "an\u0303o", "año"},
+		{IgnoreAccents, "ã", "a"},
+		{FoldCase | IgnoreAccents, "AÑO", "año"},
+		{FoldCase | IgnoreAccents, "CANCIÓN", "cancion"},
+		{FoldCase | NFKC, "ＡＢＣ", "abc"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.mode.Normalize(test.input), "%q con modo %d", test.input, test.mode)
+	}
+}
+
+func TestNormalizedHashTableNFC(t *testing.T) {
+	ht := NewNormalizedHashTable[string](0, 0, NFC)
+	ht.Put(cancionNFC, "song")
+
+	v, ok := ht.Get(cancionNFD)
+	assert.True(t, ok)
+	assert.Equal(t, "song", v)
+	_, ok = ht.Get("canción")
+	assert.False(t, ok)
+}
+
+func TestNormalizedHashTableFoldCase(t *testing.T) {
+	ht := NewNormalizedHashTable[int](0, 0, FoldCase|IgnoreAccents)
+	ht.Put(cancionNFD, 1)
+	ht.Put("CANCIÓN", 2)
+	ht.Put("cancion", 3)
+
+	assert.Equal(t, uint(1), ht.Size())
+	assert.Equal(t, []string{cancionNFD}, ht.Keys(), "se conserva la clave agregada primero")
+	v, _ := ht.Get("Cancion")
+	assert.Equal(t, 3, v)
+
+	assert.True(t, ht.Remove("cAnCiÓn"))
+	assert.True(t, ht.IsEmpty())
+}
+
+func TestNormalizedHashTableSinModo(t *testing.T) {
+	ht := NewNormalizedHashTable[int](0, 0, 0)
+	ht.Put(cancionNFC, 1)
+	ht.Put(cancionNFD, 2)
+
+	assert.Equal(t, uint(2), ht.Size())
+}
+
+func TestNormalizedHashTableNormalizaUnaVezPorOperacion(t *testing.T) {
+	ht := NewNormalizedHashTable[int](0, 0, FoldCase|IgnoreAccents)
+	calls := 0
+	normalize := ht.canonical
+	ht.canonical = func(s string) string {
+		calls++
+		return normalize(s)
+	}
+
+	for i := range 100 {
+		ht.Put(fmt.Sprintf("Canción %d", i), i)
+	}
+	assert.Equal(t, 100, calls)
+
+	calls = 0
+	for i := range 100 {
+		v, ok := ht.Get(fmt.Sprintf("CANCION %d", i))
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	assert.True(t, ht.Remove("cancion 0"))
+	assert.Equal(t, 101, calls)
+
+	assert.Contains(t, ht.Keys(), "Canción 1")
+	clone := ht.Clone()
+	assert.True(t, clone.Remove("CANCIÓN 1"))
+	_, ok := ht.Get("cancion 1")
+	assert.True(t, ok)
+}
+
+func TestNormalizedHashTableEnieEsOtraLetra(t *testing.T) {
+	ht := NewNormalizedHashTable[string](0, 0, FoldCase|IgnoreAccents)
+	ht.Put("año", "year")
+	ht.Put("ano", "anus")
+
+	assert.Equal(t, uint(2), ht.Size())
+	assert.Equal(t, "year", ht.MustGet("AÑO"))
+	assert.Equal(t, "anus", ht.MustGet("Anó"))
+}
+
+func TestNormalizedHashTableUsaLaSemilla(t *testing.T) {
+	ht := NewNormalizedHashTable[int](0, 0, FoldCase)
+	ht.Put("Canción", 1)
+	assert.Equal(t, uint(maphash.String(ht.seed, "canción")), ht.hash("canción"))
+
+	// Al cambiar la semilla, las claves se siguen encontrando.
+	ht.reseed()
+	assert.Equal(t, uint(maphash.String(ht.seed, "canción")), ht.hash("canción"))
+	assert.Equal(t, 1, ht.MustGet("CANCIÓN"))
+}
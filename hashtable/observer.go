@@ -0,0 +1,48 @@
+package hashtable
+
+import "time"
+
+// Observer recibe notificaciones de lo que ocurre dentro de una tabla de hash,
+// por ejemplo para llevar métricas o trazas sin modificar este paquete.
+//
+// Las notificaciones se realizan de forma sincrónica, durante la operación
+// que las produce, por lo que los métodos deben ser rápidos y no deben
+// modificar la tabla.
+//
+// Para implementar solo algunos métodos, se puede incluir NopObserver en el
+// tipo propio.
+type Observer interface {
+	// Probe se invoca por cada posición del arreglo que se examina al buscar,
+	// agregar o eliminar una clave. step es 0 para la posición inicial de la
+	// clave y se incrementa en cada paso de la prueba lineal.
+	Probe(index uint, step uint)
+	// Collision se invoca al agregar una clave cuya posición está ocupada por
+	// otra clave.
+	Collision(index uint)
+	// Resize se invoca luego de reubicar todos los elementos de la tabla, con
+	// la capacidad anterior, la nueva y el tiempo que llevó. Si la tabla
+	// cambió su semilla, ambas capacidades son iguales.
+	Resize(oldCapacity uint, newCapacity uint, duration time.Duration)
+	// TombstoneCreated se invoca al eliminar una clave, cuya posición queda
+	// marcada como eliminada.
+	TombstoneCreated(index uint)
+	// TombstoneReused se invoca al agregar una clave en una posición marcada
+	// como eliminada.
+	TombstoneReused(index uint)
+}
+
+// NopObserver es un Observer que ignora todas las notificaciones.
+type NopObserver struct{}
+
+func (NopObserver) Probe(uint, uint)                 {}
+func (NopObserver) Collision(uint)                   {}
+func (NopObserver) Resize(uint, uint, time.Duration) {}
+func (NopObserver) TombstoneCreated(uint)            {}
+func (NopObserver) TombstoneReused(uint)             {}
+
+// SetObserver establece el observador que recibe las notificaciones de la
+// tabla. Si es nil, la tabla deja de notificar y no se agrega ningún costo a
+// las operaciones.
+func (ht *HashTable[K, V]) SetObserver(observer Observer) {
+	ht.observer = observer
+}
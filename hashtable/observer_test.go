@@ -0,0 +1,120 @@
+package hashtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resizeEvent es una notificación de Resize registrada por countingObserver.
+type resizeEvent struct {
+	oldCapacity, newCapacity uint
+	duration                 time.Duration
+}
+
+// countingObserver registra las notificaciones recibidas.
+type countingObserver struct {
+	probes     int
+	maxStep    uint
+	collisions int
+	resizes    []resizeEvent
+	created    []uint
+	reused     []uint
+}
+
+func (o *countingObserver) Probe(index uint, step uint) {
+	o.probes++
+	o.maxStep = max(o.maxStep, step)
+}
+
+func (o *countingObserver) Collision(index uint) { o.collisions++ }
+
+func (o *countingObserver) Resize(oldCapacity, newCapacity uint, duration time.Duration) {
+	o.resizes = append(o.resizes, resizeEvent{oldCapacity, newCapacity, duration})
+}
+
+func (o *countingObserver) TombstoneCreated(index uint) { o.created = append(o.created, index) }
+
+func (o *countingObserver) TombstoneReused(index uint) { o.reused = append(o.reused, index) }
+
+func TestObserverColisiones(t *testing.T) {
+	ht := NewLegacyHashTable[string, int](0, 0)
+	observer := &countingObserver{}
+	ht.SetObserver(observer)
+	keys := collidingKeys(3)
+	for i, key := range keys {
+		ht.Put(key, i)
+	}
+
+	// La clave i colisiona con las i claves anteriores.
+	assert.Equal(t, 0+1+2+3+4+5+6+7, observer.collisions)
+	assert.Equal(t, uint(len(keys)-1), observer.maxStep)
+	assert.Equal(t, 8+observer.collisions, observer.probes)
+}
+
+func TestObserverResize(t *testing.T) {
+	ht := NewHashTable[int, int](5, 0.75)
+	observer := &countingObserver{}
+	ht.SetObserver(observer)
+	for i := 1; i <= 4; i++ {
+		ht.Put(i, i)
+	}
+
+	require.Len(t, observer.resizes, 1)
+	assert.Equal(t, uint(5), observer.resizes[0].oldCapacity)
+	assert.Equal(t, uint(11), observer.resizes[0].newCapacity)
+	assert.GreaterOrEqual(t, observer.resizes[0].duration, time.Duration(0))
+}
+
+func TestObserverReseed(t *testing.T) {
+	ht := NewHashTable[int, int](0, 0)
+	observer := &countingObserver{}
+	ht.SetObserver(observer)
+	ht.Put(1, 1)
+	ht.reseed()
+
+	require.Len(t, observer.resizes, 1)
+	assert.Equal(t, ht.capacity, observer.resizes[0].oldCapacity)
+	assert.Equal(t, ht.capacity, observer.resizes[0].newCapacity)
+}
+
+func TestObserverTombstones(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	observer := &countingObserver{}
+	ht.SetObserver(observer)
+	ht.Put("uno", 1)
+	index, _ := ht.getIndex("uno")
+
+	ht.Remove("uno")
+	ht.Remove("uno")
+	assert.Equal(t, []uint{index}, observer.created)
+
+	ht.Put("uno", 1)
+	assert.Equal(t, []uint{index}, observer.reused)
+}
+
+func TestObserverNil(t *testing.T) {
+	ht := NewHashTable[string, int](0, 0)
+	ht.SetObserver(&countingObserver{})
+	ht.SetObserver(nil)
+
+	assert.NotPanics(t, func() {
+		ht.Put("uno", 1)
+		ht.Get("uno")
+		ht.Remove("uno")
+		ht.resize()
+	})
+}
+
+func TestNopObserver(t *testing.T) {
+	type partialObserver struct {
+		NopObserver
+	}
+	ht := NewHashTable[string, int](0, 0)
+	ht.SetObserver(partialObserver{})
+
+	assert.True(t, ht.Put("uno", 1))
+	assert.True(t, ht.Remove("uno"))
+}
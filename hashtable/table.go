@@ -0,0 +1,163 @@
+package hashtable
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Table es el conjunto de métodos común a las tablas de hash del paquete:
+// HashTable, LinearHashTable y ExtendibleHashTable.
+type Table[K any, V any] interface {
+	// Put agrega o actualiza un par clave-valor.
+	Put(key K, value V) bool
+	// TryPut es como Put, pero devuelve un error que indica por qué no se
+	// agregó el par.
+	TryPut(key K, value V) error
+	// Get devuelve el valor asociado a la clave y si la clave existe.
+	Get(key K) (V, bool)
+	// TryGet es como Get, pero devuelve un error si no encuentra la clave.
+	TryGet(key K) (V, error)
+	// MustGet es como Get, pero entra en pánico si no encuentra la clave.
+	MustGet(key K) V
+	// Remove elimina la clave y devuelve si existía.
+	Remove(key K) bool
+	// TryRemove es como Remove, pero devuelve un error si no encuentra la
+	// clave.
+	TryRemove(key K) error
+	// Keys devuelve las claves de la tabla.
+	Keys() []K
+	// Values devuelve los valores de la tabla.
+	Values() []V
+	// Size devuelve la cantidad de elementos de la tabla.
+	Size() uint
+	// IsEmpty devuelve true si la tabla está vacía.
+	IsEmpty() bool
+	// Clear elimina todos los elementos de la tabla.
+	Clear()
+	// String devuelve una representación en cadena de la tabla.
+	String() string
+}
+
+var (
+	_ Table[string, int] = (*HashTable[string, int])(nil)
+	_ Table[string, int] = (*LinearHashTable[string, int])(nil)
+	_ Table[string, int] = (*ExtendibleHashTable[string, int])(nil)
+)
+
+// DefaultBucketSize es la cantidad de elementos por bucket por defecto de las
+// tablas de hashing dinámico.
+const DefaultBucketSize = 4
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// bucket es un bucket de una tabla de hashing dinámico, que almacena varios
+// pares clave-valor. Si se llena, los pares adicionales quedan en páginas de
+// desborde encadenadas a él, que también son buckets.
+type bucket[K comparable, V any] struct {
+	entries []hashTableEntry[K, V]
+	// overflow es la siguiente página de la cadena de desborde, o nil.
+	overflow *bucket[K, V]
+}
+
+// find devuelve la posición de la clave en la página, o -1 si no existe.
+func (b *bucket[K, V]) find(key K) int {
+	for i, entry := range b.entries {
+		if entry.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookup devuelve la página de la cadena de b que contiene la clave y su
+// posición en ella, o -1 si no existe.
+func (b *bucket[K, V]) lookup(key K) (*bucket[K, V], int) {
+	for page := b; page != nil; page = page.overflow {
+		if i := page.find(key); i >= 0 {
+			return page, i
+		}
+	}
+	return nil, -1
+}
+
+// insert agrega un par a la última página de la cadena de b, o a una nueva
+// página de desborde si está llena.
+func (b *bucket[K, V]) insert(entry hashTableEntry[K, V], pageSize uint) {
+	for b.overflow != nil {
+		b = b.overflow
+	}
+	if uint(len(b.entries)) >= pageSize {
+		b.overflow = &bucket[K, V]{}
+		b = b.overflow
+	}
+	b.entries = append(b.entries, entry)
+}
+
+// delete elimina el par en la posición i de la página dada de la cadena de b.
+// El hueco se completa con el último par de la cadena, y la última página se
+// libera si queda vacía.
+func (b *bucket[K, V]) delete(page *bucket[K, V], i int) {
+	var prev *bucket[K, V]
+	last := b
+	for last.overflow != nil {
+		prev, last = last, last.overflow
+	}
+	if page != last {
+		page.entries[i] = last.entries[len(last.entries)-1]
+		i = len(last.entries) - 1
+	}
+	last.remove(i)
+	if len(last.entries) == 0 && prev != nil {
+		prev.overflow = nil
+	}
+}
+
+// remove elimina la entrada en la posición i de la página, reemplazándola por
+// la última.
+func (b *bucket[K, V]) remove(i int) {
+	last := len(b.entries) - 1
+	b.entries[i] = b.entries[last]
+	b.entries[last] = hashTableEntry[K, V]{}
+	b.entries = b.entries[:last]
+}
+
+// drain quita y devuelve todos los pares de la cadena de b.
+func (b *bucket[K, V]) drain() []hashTableEntry[K, V] {
+	var entries []hashTableEntry[K, V]
+	for page := b; page != nil; page = page.overflow {
+		entries = append(entries, page.entries...)
+	}
+	b.entries = nil
+	b.overflow = nil
+	return entries
+}
+
+// overflowPages devuelve la cantidad de páginas de desborde de la cadena de b.
+func (b *bucket[K, V]) overflowPages() uint {
+	var pages uint
+	for page := b.overflow; page != nil; page = page.overflow {
+		pages++
+	}
+	return pages
+}
+
+// formatEntries devuelve la representación en cadena de los pares de los
+// buckets, con el mismo formato que HashTable.String.
+func formatEntries[K comparable, V any](buckets []*bucket[K, V]) string {
+	var b strings.Builder
+	b.WriteString("{")
+	first := true
+	for _, bucket := range buckets {
+		for page := bucket; page != nil; page = page.overflow {
+			for _, entry := range page.entries {
+				if !first {
+					b.WriteString(", ")
+				}
+				fmt.Fprintf(&b, "%v: %v", entry.key, entry.value)
+				first = false
+			}
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package hashtable
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tables devuelve un constructor por cada implementación de Table.
+func tables() map[string]func() Table[string, int] {
+	return map[string]func() Table[string, int]{
+		"HashTable":           func() Table[string, int] { return NewHashTable[string, int](0, 0) },
+		"LinearHashTable":     func() Table[string, int] { return NewLinearHashTable[string, int](0, 0) },
+		"ExtendibleHashTable": func() Table[string, int] { return NewExtendibleHashTable[string, int](0) },
+	}
+}
+
+func TestTable(t *testing.T) {
+	for name, newTable := range tables() {
+		t.Run(name, func(t *testing.T) {
+			table := newTable()
+			assert.True(t, table.IsEmpty())
+			assert.Equal(t, "{}", table.String())
+
+			for i := range 1000 {
+				require.True(t, table.Put(fmt.Sprintf("clave-%d", i), i))
+			}
+			assert.True(t, table.Put("clave-0", -1))
+			assert.Equal(t, uint(1000), table.Size())
+			assert.Len(t, table.Keys(), 1000)
+			assert.Len(t, table.Values(), 1000)
+			for i := 1; i < 1000; i++ {
+				assert.Equal(t, i, table.MustGet(fmt.Sprintf("clave-%d", i)))
+			}
+			assert.Equal(t, -1, table.MustGet("clave-0"))
+
+			for i := 0; i < 1000; i += 2 {
+				require.True(t, table.Remove(fmt.Sprintf("clave-%d", i)))
+			}
+			assert.Equal(t, uint(500), table.Size())
+			_, ok := table.Get("clave-0")
+			assert.False(t, ok)
+			_, err := table.TryGet("clave-0")
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.ErrorIs(t, table.TryRemove("clave-0"), ErrNotFound)
+			assert.NoError(t, table.TryRemove("clave-1"))
+
+			table.Clear()
+			assert.True(t, table.IsEmpty())
+			_, ok = table.Get("clave-3")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestTableString(t *testing.T) {
+	for name, newTable := range tables() {
+		t.Run(name, func(t *testing.T) {
+			table := newTable()
+			table.Put("uno", 1)
+
+			assert.Equal(t, "{uno: 1}", table.String())
+		})
+	}
+}
+
+func TestTableClaveInvalida(t *testing.T) {
+	tables := map[string]Table[float64, int]{
+		"HashTable":           NewHashTable[float64, int](0, 0),
+		"LinearHashTable":     NewLinearHashTable[float64, int](0, 0),
+		"ExtendibleHashTable": NewExtendibleHashTable[float64, int](0),
+	}
+	for name, table := range tables {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, table.TryPut(math.NaN(), 1), ErrInvalidKey)
+			_, err := table.TryGet(math.NaN())
+			assert.ErrorIs(t, err, ErrInvalidKey)
+			assert.True(t, table.IsEmpty())
+		})
+	}
+}
@@ -0,0 +1,305 @@
+// hyperloglog proporciona una implementación de HyperLogLog++, un estimador
+// probabilístico de la cantidad de elementos distintos (cardinalidad) de un
+// flujo de datos que utiliza memoria constante.
+//
+// Para cardinalidades pequeñas se utiliza una representación dispersa con una
+// precisión de 25 bits, que ocupa memoria proporcional a la cantidad de
+// elementos y es mucho más exacta. Cuando la representación dispersa supera un
+// umbral, se convierte a la representación densa de 2^p registros.
+//
+// No se aplica la corrección empírica del sesgo del algoritmo original: en el
+// rango en que el estimador está sesgado se utiliza conteo lineal.
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// Límites y valor por defecto de la precisión.
+const (
+	MinPrecision     uint8 = 4
+	MaxPrecision     uint8 = 18
+	DefaultPrecision uint8 = 14
+)
+
+// sparsePrecision es la precisión utilizada por la representación dispersa.
+const sparsePrecision = 25
+
+// seed es la semilla del hash aplicado a los elementos.
+const seed uint64 = 0x5bd1e9955bd1e995
+
+// HyperLogLog estima la cardinalidad de un conjunto de elementos.
+type HyperLogLog struct {
+	// precision es la cantidad de bits del hash utilizados como índice.
+	precision uint8
+	// registers contiene los registros de la representación densa, o nil si se
+	// está utilizando la representación dispersa.
+	registers []uint8
+	// sparse asocia a cada índice de 25 bits el máximo rango observado.
+	sparse map[uint32]uint8
+}
+
+// NewHyperLogLog crea un nuevo estimador con la precisión especificada. El
+// error estándar relativo de la estimación es 1.04 / sqrt(2^precision).
+//
+// - Si la precisión está fuera del rango [4, 18], se establece en 14.
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	if precision < MinPrecision || precision > MaxPrecision {
+		precision = DefaultPrecision
+	}
+	return &HyperLogLog{
+		precision: precision,
+		sparse:    make(map[uint32]uint8),
+	}
+}
+
+// Add agrega un elemento al estimador.
+func (h *HyperLogLog) Add(item []byte) {
+	h.addHash(hashing.Sum64(item, seed))
+}
+
+// AddString agrega una cadena al estimador.
+func (h *HyperLogLog) AddString(item string) {
+	h.addHash(hashing.String64(item, seed))
+}
+
+// Estimate devuelve la cantidad estimada de elementos distintos agregados.
+func (h *HyperLogLog) Estimate() uint64 {
+	if h.registers == nil {
+		// Conteo lineal sobre los 2^25 registros de la representación dispersa.
+		m := float64(uint64(1) << sparsePrecision)
+		return uint64(math.Round(m * math.Log(m/(m-float64(len(h.sparse))))))
+	}
+
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(len(h.registers)) * m * m / sum
+	if zeros > 0 && estimate <= 2.5*m {
+		// En el rango bajo el estimador está sesgado y el conteo lineal es
+		// más exacto.
+		return uint64(math.Round(m * math.Log(m/float64(zeros))))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// Merge combina los elementos de otro estimador en el actual, de modo que el
+// resultado estima la cardinalidad de la unión.
+//
+// Devuelve un error si los estimadores tienen distinta precisión.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return fmt.Errorf("hyperloglog: no se pueden combinar precisiones %d y %d",
+			h.precision, other.precision)
+	}
+	if h.registers == nil && other.registers == nil {
+		for index, rho := range other.sparse {
+			h.sparse[index] = max(h.sparse[index], rho)
+		}
+		h.checkSparse()
+		return nil
+	}
+	h.toDense()
+	if other.registers != nil {
+		for i, r := range other.registers {
+			h.registers[i] = max(h.registers[i], r)
+		}
+		return nil
+	}
+	for index, rho := range other.sparse {
+		h.mergeSparseEntry(index, rho)
+	}
+	return nil
+}
+
+// Precision devuelve la precisión del estimador.
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// IsSparse devuelve true si el estimador utiliza la representación dispersa.
+func (h *HyperLogLog) IsSparse() bool {
+	return h.registers == nil
+}
+
+// Clear elimina todos los elementos del estimador y vuelve a la
+// representación dispersa.
+func (h *HyperLogLog) Clear() {
+	h.registers = nil
+	h.sparse = make(map[uint32]uint8)
+}
+
+// String devuelve una representación en cadena del estimador.
+func (h *HyperLogLog) String() string {
+	mode := "dense"
+	if h.IsSparse() {
+		mode = "sparse"
+	}
+	return fmt.Sprintf("HyperLogLog: {precision: %d, mode: %s, estimate: %d}",
+		h.precision, mode, h.Estimate())
+}
+
+// Serialización ///////////////////////////////////////////////////////////////
+
+// magic identifica el formato binario del estimador.
+var magic = [4]byte{'H', 'L', 'L', '+'}
+
+// version es la versión del formato binario del estimador.
+const version = 1
+
+// Modos de representación en el formato binario.
+const (
+	sparseMode byte = 0
+	denseMode  byte = 1
+)
+
+// ErrInvalidFormat se devuelve al deserializar datos que no corresponden a un
+// estimador válido.
+var ErrInvalidFormat = errors.New("hyperloglog: formato inválido")
+
+// MarshalBinary serializa el estimador en un arreglo de bytes.
+//
+// El formato es: magic, versión, precisión y modo. En modo disperso le siguen
+// la cantidad de entradas y cada entrada (índice de 4 bytes y rango de 1 byte)
+// ordenadas por índice; en modo denso, los 2^p registros.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := append(magic[:], version, h.precision)
+	if h.registers != nil {
+		data = append(data, denseMode)
+		return append(data, h.registers...), nil
+	}
+	data = append(data, sparseMode)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(h.sparse)))
+	indexes := make([]uint32, 0, len(h.sparse))
+	for index := range h.sparse {
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	for _, index := range indexes {
+		data = binary.LittleEndian.AppendUint32(data, index)
+		data = append(data, h.sparse[index])
+	}
+	return data, nil
+}
+
+// UnmarshalBinary reemplaza el contenido del estimador por el serializado en
+// data.
+//
+// Devuelve ErrInvalidFormat si los datos no son válidos.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 7 || [4]byte(data[:4]) != magic {
+		return ErrInvalidFormat
+	}
+	if data[4] != version {
+		return fmt.Errorf("%w: versión %d no soportada", ErrInvalidFormat, data[4])
+	}
+	precision, mode, data := data[5], data[6], data[7:]
+	if precision < MinPrecision || precision > MaxPrecision {
+		return ErrInvalidFormat
+	}
+	switch mode {
+	case denseMode:
+		if len(data) != 1<<precision {
+			return ErrInvalidFormat
+		}
+		*h = HyperLogLog{precision: precision, registers: slices.Clone(data)}
+	case sparseMode:
+		if len(data) < 4 {
+			return ErrInvalidFormat
+		}
+		n := int(binary.LittleEndian.Uint32(data))
+		data = data[4:]
+		if len(data) != 5*n {
+			return ErrInvalidFormat
+		}
+		sparse := make(map[uint32]uint8, n)
+		for i := 0; i < n; i++ {
+			index := binary.LittleEndian.Uint32(data[5*i:])
+			if index >= 1<<sparsePrecision {
+				return ErrInvalidFormat
+			}
+			sparse[index] = data[5*i+4]
+		}
+		*h = HyperLogLog{precision: precision, sparse: sparse}
+	default:
+		return ErrInvalidFormat
+	}
+	return nil
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// addHash registra el hash de un elemento.
+func (h *HyperLogLog) addHash(hash uint64) {
+	if h.registers != nil {
+		index := hash >> (64 - h.precision)
+		rho := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1))) + 1
+		h.registers[index] = max(h.registers[index], rho)
+		return
+	}
+	index := uint32(hash >> (64 - sparsePrecision))
+	rho := uint8(bits.LeadingZeros64(hash<<sparsePrecision|1<<(sparsePrecision-1))) + 1
+	h.sparse[index] = max(h.sparse[index], rho)
+	h.checkSparse()
+}
+
+// checkSparse convierte el estimador a la representación densa cuando la
+// representación dispersa ocupa más memoria que los registros densos.
+func (h *HyperLogLog) checkSparse() {
+	if h.registers == nil && len(h.sparse) > (1<<h.precision)/4 {
+		h.toDense()
+	}
+}
+
+// toDense convierte el estimador a la representación densa.
+func (h *HyperLogLog) toDense() {
+	if h.registers != nil {
+		return
+	}
+	h.registers = make([]uint8, 1<<h.precision)
+	for index, rho := range h.sparse {
+		h.mergeSparseEntry(index, rho)
+	}
+	h.sparse = nil
+}
+
+// mergeSparseEntry incorpora una entrada dispersa a los registros densos. Los
+// bits del índice disperso que no forman parte del índice denso se usan para
+// calcular el rango con la precisión densa.
+func (h *HyperLogLog) mergeSparseEntry(index uint32, rho uint8) {
+	shift := sparsePrecision - h.precision
+	denseIndex := index >> shift
+	rest := index & (1<<shift - 1)
+	if rest != 0 {
+		rho = uint8(bits.LeadingZeros32(rest<<(32-shift))) + 1
+	} else {
+		rho += shift
+	}
+	h.registers[denseIndex] = max(h.registers[denseIndex], rho)
+}
+
+// alpha devuelve la constante de corrección del estimador para m registros.
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
@@ -0,0 +1,179 @@
+package hyperloglog
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// relativeError devuelve el error relativo de estimate respecto de exact.
+func relativeError(estimate uint64, exact int) float64 {
+	return math.Abs(float64(estimate)-float64(exact)) / float64(exact)
+}
+
+// generate devuelve n cadenas pseudoaleatorias, con repeticiones, a partir de
+// un universo de distinct valores.
+func generate(n, distinct int, seed uint64) []string {
+	r := rand.New(rand.NewPCG(seed, seed))
+	data := make([]string, n)
+	for i := range data {
+		data[i] = fmt.Sprintf("usuario-%d", r.IntN(distinct))
+	}
+	return data
+}
+
+func TestNewHyperLogLog(t *testing.T) {
+	h := NewHyperLogLog(10)
+
+	assert.Equal(t, uint8(10), h.Precision())
+	assert.True(t, h.IsSparse())
+	assert.Equal(t, uint64(0), h.Estimate())
+}
+
+func TestNewHyperLogLogPrecisionInvalida(t *testing.T) {
+	assert.Equal(t, DefaultPrecision, NewHyperLogLog(0).Precision())
+	assert.Equal(t, DefaultPrecision, NewHyperLogLog(19).Precision())
+}
+
+func TestHyperLogLogDispersoEsExacto(t *testing.T) {
+	h := NewHyperLogLog(14)
+	for i := range 1000 {
+		h.AddString(fmt.Sprintf("elemento-%d", i))
+		h.AddString(fmt.Sprintf("elemento-%d", i))
+	}
+
+	assert.True(t, h.IsSparse())
+	assert.InDelta(t, 1000, h.Estimate(), 2)
+}
+
+func TestHyperLogLogPasaADenso(t *testing.T) {
+	h := NewHyperLogLog(10)
+	for i := range 1000 {
+		h.Add(fmt.Appendf(nil, "elemento-%d", i))
+	}
+
+	assert.False(t, h.IsSparse())
+	assert.Less(t, relativeError(h.Estimate(), 1000), 0.1)
+}
+
+func TestHyperLogLogCardinalidadGrande(t *testing.T) {
+	for _, precision := range []uint8{10, 12, 14} {
+		h := NewHyperLogLog(precision)
+		for i := range 200000 {
+			h.AddString(fmt.Sprintf("elemento-%d", i))
+		}
+		stdErr := 1.04 / math.Sqrt(float64(uint(1)<<precision))
+		assert.Less(t, relativeError(h.Estimate(), 200000), 4*stdErr, "precisión %d", precision)
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a := NewHyperLogLog(12)
+	b := NewHyperLogLog(12)
+	for i := range 30000 {
+		a.AddString(fmt.Sprintf("elemento-%d", i))
+	}
+	for i := 20000; i < 50000; i++ {
+		b.AddString(fmt.Sprintf("elemento-%d", i))
+	}
+
+	require.NoError(t, a.Merge(b))
+	assert.Less(t, relativeError(a.Estimate(), 50000), 0.07)
+}
+
+func TestHyperLogLogMergeDispersoConDenso(t *testing.T) {
+	sparse := NewHyperLogLog(12)
+	dense := NewHyperLogLog(12)
+	for i := range 100 {
+		sparse.AddString(fmt.Sprintf("a-%d", i))
+	}
+	for i := range 5000 {
+		dense.AddString(fmt.Sprintf("b-%d", i))
+	}
+	require.True(t, sparse.IsSparse())
+	require.False(t, dense.IsSparse())
+
+	require.NoError(t, sparse.Merge(dense))
+	assert.False(t, sparse.IsSparse())
+	assert.Less(t, relativeError(sparse.Estimate(), 5100), 0.07)
+}
+
+func TestHyperLogLogMergePrecisionDistinta(t *testing.T) {
+	assert.Error(t, NewHyperLogLog(10).Merge(NewHyperLogLog(12)))
+}
+
+func TestHyperLogLogClear(t *testing.T) {
+	h := NewHyperLogLog(4)
+	for i := range 100 {
+		h.AddString(fmt.Sprintf("elemento-%d", i))
+	}
+
+	h.Clear()
+	assert.True(t, h.IsSparse())
+	assert.Equal(t, uint64(0), h.Estimate())
+}
+
+func TestHyperLogLogMarshalBinary(t *testing.T) {
+	for _, n := range []int{10, 10000} {
+		h := NewHyperLogLog(12)
+		for i := range n {
+			h.AddString(fmt.Sprintf("elemento-%d", i))
+		}
+
+		data, err := h.MarshalBinary()
+		require.NoError(t, err)
+
+		g := &HyperLogLog{}
+		require.NoError(t, g.UnmarshalBinary(data))
+		assert.Equal(t, h.IsSparse(), g.IsSparse())
+		assert.Equal(t, h.Estimate(), g.Estimate())
+	}
+}
+
+func TestHyperLogLogUnmarshalBinaryInvalido(t *testing.T) {
+	h := NewHyperLogLog(12)
+	h.AddString("hola")
+	data, _ := h.MarshalBinary()
+
+	assert.ErrorIs(t, (&HyperLogLog{}).UnmarshalBinary(nil), ErrInvalidFormat)
+	assert.ErrorIs(t, (&HyperLogLog{}).UnmarshalBinary(data[:len(data)-1]), ErrInvalidFormat)
+}
+
+func TestHyperLogLogString(t *testing.T) {
+	h := NewHyperLogLog(14)
+	h.AddString("hola")
+
+	assert.Equal(t, "HyperLogLog: {precision: 14, mode: sparse, estimate: 1}", h.String())
+}
+
+// TestHyperLogLogContraConteoExacto compara la estimación con la cardinalidad
+// exacta, calculada con una hashtable.HashTable, sobre datos generados con
+// repeticiones.
+func TestHyperLogLogContraConteoExacto(t *testing.T) {
+	cases := []struct {
+		n, distinct int
+	}{
+		{100, 50},
+		{10000, 2000},
+		{100000, 60000},
+		{300000, 1000000},
+	}
+	for _, c := range cases {
+		data := generate(c.n, c.distinct, uint64(c.n))
+		exact := hashtable.NewHashTable[string, struct{}](0, 0)
+		h := NewHyperLogLog(DefaultPrecision)
+		for _, item := range data {
+			exact.Put(item, struct{}{})
+			h.AddString(item)
+		}
+		stdErr := 1.04 / math.Sqrt(float64(uint(1)<<DefaultPrecision))
+		assert.Less(t, relativeError(h.Estimate(), int(exact.Size())), 4*stdErr,
+			"n: %d, exacto: %d, estimado: %d", c.n, exact.Size(), h.Estimate())
+	}
+}
@@ -0,0 +1,121 @@
+// intern proporciona tablas de internado de cadenas: cada cadena distinta se
+// almacena una única vez y se la representa con una cadena canónica o con un
+// identificador entero compacto (Handle).
+//
+// Es útil al procesar textos con muchas palabras repetidas: en lugar de
+// conservar millones de copias de la misma palabra, se conservan referencias
+// a una única copia.
+package intern
+
+import (
+	"fmt"
+	"strings"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// Handle identifica a una cadena internada. Los identificadores se asignan en
+// orden, empezando en 0.
+type Handle uint32
+
+// Stats resume el uso de un Interner.
+type Stats struct {
+	// Distinct es la cantidad de cadenas distintas internadas.
+	Distinct int
+	// Requests es la cantidad de cadenas recibidas, incluidas las repetidas.
+	Requests uint64
+	// Bytes es la cantidad de bytes de las cadenas almacenadas.
+	Bytes uint64
+	// SavedBytes es la cantidad de bytes de las cadenas repetidas que se
+	// reemplazaron por la cadena canónica, y que por lo tanto pueden
+	// liberarse.
+	SavedBytes uint64
+}
+
+// Interner es una tabla de internado de cadenas, implementada sobre una
+// hashtable.HashTable que asocia cada cadena a su Handle.
+//
+// No es segura para uso concurrente; para eso se puede usar SyncInterner.
+type Interner struct {
+	// handles asocia cada cadena con su identificador.
+	handles *hashtable.HashTable[string, Handle]
+	// strings contiene las cadenas canónicas, indexadas por su identificador.
+	strings []string
+	// stats lleva las estadísticas de uso.
+	stats Stats
+}
+
+// New crea una nueva tabla de internado vacía.
+//
+// Uso:
+//
+//	in := intern.New()
+//	word := in.Intern(line[start:end]) // Devuelve la copia canónica de la palabra.
+func New() *Interner {
+	return &Interner{handles: hashtable.NewHashTable[string, Handle](0, 0)}
+}
+
+// Intern devuelve la cadena canónica igual a s.
+//
+// La primera vez que se interna una cadena, se almacena una copia, de modo que
+// la cadena canónica no retiene la memoria de una cadena mayor de la que s
+// pueda ser una subcadena.
+func (in *Interner) Intern(s string) string {
+	return in.strings[in.Handle(s)]
+}
+
+// Handle devuelve el identificador de la cadena s, internándola si es
+// necesario.
+//
+// - Si se internan más de 2^32 cadenas distintas, entra en pánico.
+func (in *Interner) Handle(s string) Handle {
+	in.stats.Requests++
+	if h, ok := in.handles.Get(s); ok {
+		in.stats.SavedBytes += uint64(len(s))
+		return h
+	}
+	if len(in.strings) > int(^Handle(0)) {
+		panic("intern: demasiadas cadenas distintas")
+	}
+	h := Handle(len(in.strings))
+	canonical := strings.Clone(s)
+	in.strings = append(in.strings, canonical)
+	in.handles.Put(canonical, h)
+	in.stats.Distinct++
+	in.stats.Bytes += uint64(len(s))
+	return h
+}
+
+// Find devuelve el identificador de la cadena s y true, si fue internada.
+//
+// - Si la cadena no fue internada, devuelve 0 y false, sin internarla.
+func (in *Interner) Find(s string) (Handle, bool) {
+	return in.handles.Get(s)
+}
+
+// Lookup devuelve la cadena con el identificador dado y true.
+//
+// - Si el identificador no corresponde a ninguna cadena, devuelve "" y false.
+func (in *Interner) Lookup(h Handle) (string, bool) {
+	if int(h) >= len(in.strings) {
+		return "", false
+	}
+	return in.strings[h], true
+}
+
+// Size devuelve la cantidad de cadenas distintas internadas.
+func (in *Interner) Size() int {
+	return len(in.strings)
+}
+
+// Stats devuelve las estadísticas de uso de la tabla.
+func (in *Interner) Stats() Stats {
+	return in.stats
+}
+
+// String devuelve una representación en cadena de la tabla, con sus
+// estadísticas.
+func (in *Interner) String() string {
+	return fmt.Sprintf("Interner: %d cadenas, %d bytes, %d bytes ahorrados",
+		in.stats.Distinct, in.stats.Bytes, in.stats.SavedBytes)
+}
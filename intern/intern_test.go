@@ -0,0 +1,96 @@
+package intern
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntern(t *testing.T) {
+	in := New()
+	line := "hola mundo hola"
+	first := in.Intern(line[0:4])
+	second := in.Intern(line[11:15])
+
+	assert.Equal(t, "hola", first)
+	assert.Same(t, unsafe.StringData(first), unsafe.StringData(second), "comparten la misma copia")
+	assert.NotSame(t, unsafe.StringData(line), unsafe.StringData(first), "no retiene la línea original")
+	assert.Equal(t, 1, in.Size())
+}
+
+func TestInternHandle(t *testing.T) {
+	in := New()
+
+	assert.Equal(t, Handle(0), in.Handle("uno"))
+	assert.Equal(t, Handle(1), in.Handle("dos"))
+	assert.Equal(t, Handle(0), in.Handle("uno"))
+	assert.Equal(t, Handle(2), in.Handle(""))
+
+	s, ok := in.Lookup(1)
+	assert.True(t, ok)
+	assert.Equal(t, "dos", s)
+	_, ok = in.Lookup(3)
+	assert.False(t, ok)
+
+	h, ok := in.Find("uno")
+	assert.True(t, ok)
+	assert.Equal(t, Handle(0), h)
+	_, ok = in.Find("tres")
+	assert.False(t, ok)
+	assert.Equal(t, 3, in.Size(), "Find no interna")
+}
+
+func TestInternStats(t *testing.T) {
+	in := New()
+	for _, word := range strings.Fields("el perro y el gato y el loro") {
+		in.Intern(word)
+	}
+
+	stats := in.Stats()
+	assert.Equal(t, 5, stats.Distinct)
+	assert.Equal(t, uint64(8), stats.Requests)
+	assert.Equal(t, uint64(len("elperroygatoloro")), stats.Bytes)
+	assert.Equal(t, uint64(len("elyel")), stats.SavedBytes)
+	assert.Equal(t, "Interner: 5 cadenas, 16 bytes, 5 bytes ahorrados", in.String())
+}
+
+func TestSyncInterner(t *testing.T) {
+	si := NewSync()
+	words := make([]string, 100)
+	for i := range words {
+		words[i] = fmt.Sprintf("palabra-%d", i)
+	}
+
+	var wg sync.WaitGroup
+	handles := make([][]Handle, 8)
+	for g := range handles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, word := range words {
+				handles[g] = append(handles[g], si.Handle(word))
+				si.Intern(word)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 100, si.Size())
+	for g := range handles {
+		assert.Equal(t, handles[0], handles[g])
+	}
+	for i, word := range words {
+		s, ok := si.Lookup(handles[0][i])
+		assert.True(t, ok)
+		assert.Equal(t, word, s)
+	}
+	stats := si.Stats()
+	assert.Equal(t, uint64(2*8*100), stats.Requests)
+	assert.Equal(t, 100, stats.Distinct)
+	assert.Equal(t, stats.Bytes*(2*8-1), stats.SavedBytes)
+}
@@ -0,0 +1,82 @@
+package intern
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SyncInterner es una tabla de internado de cadenas segura para uso
+// concurrente.
+//
+// Las búsquedas de cadenas ya internadas pueden realizarse en paralelo; solo
+// internar una cadena nueva requiere acceso exclusivo.
+type SyncInterner struct {
+	mu sync.RWMutex
+	in *Interner
+	// requests y saved cuentan las búsquedas realizadas con el bloqueo
+	// compartido, que no pueden modificar las estadísticas de in.
+	requests, saved atomic.Uint64
+}
+
+// NewSync crea una nueva tabla de internado vacía, segura para uso
+// concurrente.
+func NewSync() *SyncInterner {
+	return &SyncInterner{in: New()}
+}
+
+// Intern devuelve la cadena canónica igual a s (ver Interner.Intern).
+func (si *SyncInterner) Intern(s string) string {
+	h := si.Handle(s)
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.in.strings[h]
+}
+
+// Handle devuelve el identificador de la cadena s, internándola si es
+// necesario (ver Interner.Handle).
+func (si *SyncInterner) Handle(s string) Handle {
+	si.mu.RLock()
+	h, ok := si.in.Find(s)
+	si.mu.RUnlock()
+	if ok {
+		si.requests.Add(1)
+		si.saved.Add(uint64(len(s)))
+		return h
+	}
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	return si.in.Handle(s)
+}
+
+// Find devuelve el identificador de la cadena s y true, si fue internada.
+func (si *SyncInterner) Find(s string) (Handle, bool) {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.in.Find(s)
+}
+
+// Lookup devuelve la cadena con el identificador dado y true.
+//
+// - Si el identificador no corresponde a ninguna cadena, devuelve "" y false.
+func (si *SyncInterner) Lookup(h Handle) (string, bool) {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.in.Lookup(h)
+}
+
+// Size devuelve la cantidad de cadenas distintas internadas.
+func (si *SyncInterner) Size() int {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.in.Size()
+}
+
+// Stats devuelve las estadísticas de uso de la tabla.
+func (si *SyncInterner) Stats() Stats {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	stats := si.in.Stats()
+	stats.Requests += si.requests.Load()
+	stats.SavedBytes += si.saved.Load()
+	return stats
+}
@@ -0,0 +1,187 @@
+package list
+
+import "errors"
+
+var (
+	// ErrConcurrentModification indica que la lista se modificó durante una
+	// iteración, sin utilizar el iterador.
+	ErrConcurrentModification = errors.New("list: la lista se modificó durante la iteración")
+	// ErrNoCurrent indica que el iterador no está posicionado en un elemento,
+	// porque todavía no se llamó a Next o porque el elemento ya se eliminó.
+	ErrNoCurrent = errors.New("list: el iterador no está posicionado en un elemento")
+)
+
+// Iterator recorre los elementos de una LinkedList.
+//
+// El iterador es fail-fast: si la lista se modifica durante la iteración sin
+// utilizar el iterador, Next devuelve false y Err devuelve
+// ErrConcurrentModification, o bien entra en pánico si así se configuró con
+// SetPanicOnModification. Modificar el dato de un nodo con SetData no es una
+// modificación estructural.
+type Iterator[T comparable] struct {
+	// list es la lista que se recorre.
+	list *LinkedList[T]
+	// prev es el nodo anterior al actual, o nil si el actual es el primero.
+	prev *LinkedNode[T]
+	// current es el nodo actual, o nil si el iterador no está posicionado.
+	current *LinkedNode[T]
+	// following es el nodo siguiente al último eliminado con Remove.
+	following *LinkedNode[T]
+	// started indica si ya se llamó a Next.
+	started bool
+	// removed indica si el nodo actual se eliminó con Remove.
+	removed bool
+	// expected es el contador de modificaciones esperado en la lista.
+	expected int
+	// panics indica si se entra en pánico al detectar una modificación.
+	panics bool
+	// err es el error que detuvo la iteración.
+	err error
+}
+
+// Iterator devuelve un iterador posicionado antes del primer elemento de la
+// lista.
+//
+// Uso:
+//
+//	it := list.Iterator()
+//	for it.Next() {
+//		if it.Value() < 0 {
+//			it.Remove() // Elimina el elemento actual sin invalidar el iterador.
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		fmt.Println("La lista se modificó durante la iteración.")
+//	}
+//
+// Retorna:
+//   - un iterador sobre los elementos de la lista.
+func (l *LinkedList[T]) Iterator() *Iterator[T] {
+	return &Iterator[T]{list: l, expected: l.modCount}
+}
+
+// SetPanicOnModification establece si el iterador entra en pánico al detectar
+// una modificación de la lista durante la iteración, en lugar de detenerse y
+// devolver el error en Err.
+//
+// Uso:
+//
+//	it.SetPanicOnModification(true) // Entra en pánico ante una modificación.
+//
+// Parámetros:
+//   - `panics`: `true` para entrar en pánico; `false` para devolver el error.
+func (it *Iterator[T]) SetPanicOnModification(panics bool) {
+	it.panics = panics
+}
+
+// Next avanza al siguiente elemento de la lista.
+//
+// Uso:
+//
+//	for it.Next() {
+//		fmt.Println(it.Value())
+//	}
+//
+// Retorna:
+//   - `true` si avanzó a un elemento; `false` si no hay más elementos o si la
+//     lista se modificó durante la iteración (ver Err).
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil || !it.check() {
+		it.current = nil
+		return false
+	}
+	switch {
+	case !it.started:
+		it.started = true
+		it.current = it.list.head
+	case it.removed:
+		it.removed = false
+		it.current = it.following
+	case it.current != nil:
+		it.prev = it.current
+		it.current = it.current.Next()
+	}
+	return it.current != nil
+}
+
+// Value devuelve el elemento actual.
+//
+// Uso:
+//
+//	value := it.Value() // Obtiene el elemento actual.
+//
+// Retorna:
+//   - el elemento actual; el valor nulo si el iterador no está posicionado en
+//     un elemento.
+func (it *Iterator[T]) Value() T {
+	if it.current == nil || it.removed {
+		var zero T
+		return zero
+	}
+	return it.current.Data()
+}
+
+// Remove elimina de la lista el elemento actual, sin invalidar el iterador. La
+// siguiente llamada a Next avanza al elemento que seguía al eliminado.
+//
+// Uso:
+//
+//	err := it.Remove() // Elimina el elemento actual.
+//
+// Retorna:
+//   - `nil` si se eliminó el elemento; `ErrNoCurrent` si el iterador no está
+//     posicionado en un elemento; `ErrConcurrentModification` si la lista se
+//     modificó durante la iteración.
+func (it *Iterator[T]) Remove() error {
+	if it.err != nil || !it.check() {
+		return it.err
+	}
+	if it.current == nil || it.removed {
+		return ErrNoCurrent
+	}
+
+	l := it.list
+	it.following = it.current.Next()
+	if it.prev == nil {
+		l.head = it.following
+	} else {
+		it.prev.SetNext(it.following)
+	}
+	if it.current == l.tail {
+		l.tail = it.prev
+	}
+	it.current.SetNext(nil)
+	l.size--
+	l.modCount++
+
+	it.expected = l.modCount
+	it.removed = true
+	return nil
+}
+
+// Err devuelve el error que detuvo la iteración.
+//
+// Uso:
+//
+//	if err := it.Err(); err != nil {
+//		fmt.Println(err)
+//	}
+//
+// Retorna:
+//   - el error que detuvo la iteración; `nil` si la iteración terminó
+//     normalmente o no terminó.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// check verifica que la lista no se haya modificado fuera del iterador.
+func (it *Iterator[T]) check() bool {
+	if it.list.modCount == it.expected {
+		return true
+	}
+	it.err = ErrConcurrentModification
+	if it.panics {
+		panic(it.err)
+	}
+	return false
+}
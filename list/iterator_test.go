@@ -0,0 +1,131 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// values recorre la lista siguiendo los punteros y devuelve sus elementos.
+func values[T comparable](l *LinkedList[T]) []T {
+	var result []T
+	for node := l.Head(); node != nil; node = node.Next() {
+		result = append(result, node.Data())
+	}
+	return result
+}
+
+func TestINTERNALLinkedListIterator(t *testing.T) {
+	list := NewLinkedList[int]()
+	for i := 1; i <= 3; i++ {
+		list.Append(i)
+	}
+
+	var got []int
+	it := list.Iterator()
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.False(t, it.Next())
+	assert.Equal(t, 0, it.Value())
+}
+
+func TestINTERNALLinkedListIteratorOnEmptyList(t *testing.T) {
+	it := NewLinkedList[int]().Iterator()
+
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Remove(), ErrNoCurrent)
+}
+
+func TestINTERNALLinkedListIteratorDetectsModification(t *testing.T) {
+	modifications := map[string]func(l *LinkedList[int]){
+		"Append":      func(l *LinkedList[int]) { l.Append(10) },
+		"Prepend":     func(l *LinkedList[int]) { l.Prepend(10) },
+		"Remove":      func(l *LinkedList[int]) { l.Remove(2) },
+		"RemoveFirst": func(l *LinkedList[int]) { l.RemoveFirst() },
+		"RemoveLast":  func(l *LinkedList[int]) { l.RemoveLast() },
+		"Clear":       func(l *LinkedList[int]) { l.Clear() },
+	}
+	for name, modify := range modifications {
+		t.Run(name, func(t *testing.T) {
+			list := NewLinkedList[int]()
+			list.Append(1)
+			list.Append(2)
+			it := list.Iterator()
+			require.True(t, it.Next())
+
+			modify(list)
+			assert.False(t, it.Next())
+			assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+			assert.ErrorIs(t, it.Remove(), ErrConcurrentModification)
+		})
+	}
+}
+
+func TestINTERNALLinkedListIteratorRemoveMissingIsNotModification(t *testing.T) {
+	list := NewLinkedList[int]()
+	list.Append(1)
+	it := list.Iterator()
+
+	list.Remove(5)
+	assert.True(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestINTERNALLinkedListIteratorPanics(t *testing.T) {
+	list := NewLinkedList[int]()
+	list.Append(1)
+	it := list.Iterator()
+	it.SetPanicOnModification(true)
+
+	list.Append(2)
+	assert.PanicsWithError(t, ErrConcurrentModification.Error(), func() { it.Next() })
+}
+
+func TestINTERNALLinkedListIteratorRemove(t *testing.T) {
+	tests := []struct {
+		name     string
+		remove   func(int) bool
+		expected []int
+	}{
+		{"none", func(int) bool { return false }, []int{1, 2, 3, 4, 5}},
+		{"first", func(v int) bool { return v == 1 }, []int{2, 3, 4, 5}},
+		{"last", func(v int) bool { return v == 5 }, []int{1, 2, 3, 4}},
+		{"even", func(v int) bool { return v%2 == 0 }, []int{1, 3, 5}},
+		{"consecutive", func(v int) bool { return v >= 2 && v <= 4 }, []int{1, 5}},
+		{"all", func(int) bool { return true }, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list := NewLinkedList[int]()
+			for i := 1; i <= 5; i++ {
+				list.Append(i)
+			}
+
+			var visited []int
+			it := list.Iterator()
+			for it.Next() {
+				visited = append(visited, it.Value())
+				if test.remove(it.Value()) {
+					require.NoError(t, it.Remove())
+					assert.ErrorIs(t, it.Remove(), ErrNoCurrent)
+				}
+			}
+			require.NoError(t, it.Err())
+			assert.Equal(t, []int{1, 2, 3, 4, 5}, visited)
+			assert.Equal(t, test.expected, values(list))
+			assert.Equal(t, len(test.expected), list.Size())
+			if len(test.expected) > 0 {
+				assert.Equal(t, test.expected[len(test.expected)-1], list.Tail().Data())
+			} else {
+				assert.Nil(t, list.Tail())
+			}
+
+			list.Append(6)
+			assert.Equal(t, append(test.expected, 6), values(list))
+		})
+	}
+}
@@ -8,6 +8,10 @@ type LinkedList[T comparable] struct {
 	head *LinkedNode[T]
 	tail *LinkedNode[T]
 	size int
+	// modCount es la cantidad de modificaciones estructurales de la lista, que
+	// los iteradores utilizan para detectar modificaciones durante la
+	// iteración.
+	modCount int
 }
 
 // NewLinkedList crea una nueva lista vacía.
@@ -76,6 +80,7 @@ func (l *LinkedList[T]) Clear() {
 	l.head = nil
 	l.tail = nil
 	l.size = 0
+	l.modCount++
 }
 
 // Prepend inserta un dato al inicio de la lista.
@@ -95,6 +100,7 @@ func (l *LinkedList[T]) Prepend(data T) {
 	}
 	l.head = newNode
 	l.size++
+	l.modCount++
 }
 
 // Append inserta un dato al final de la lista.
@@ -114,6 +120,7 @@ func (l *LinkedList[T]) Append(data T) {
 	}
 	l.tail = newNode
 	l.size++
+	l.modCount++
 }
 
 // Find busca un dato en la lista, si lo encuentra devuelve el nodo
@@ -155,6 +162,7 @@ func (l *LinkedList[T]) RemoveFirst() {
 	}
 
 	l.size--
+	l.modCount++
 }
 
 // RemoveLast elimina el último nodo de la lista.
@@ -179,6 +187,7 @@ func (l *LinkedList[T]) RemoveLast() {
 		l.tail = current
 	}
 	l.size--
+	l.modCount++
 }
 
 // Remove elimina un la primera aparición de un dato en la lista.
@@ -214,6 +223,7 @@ func (l *LinkedList[T]) Remove(data T) {
 		l.tail = current
 	}
 	l.size--
+	l.modCount++
 }
 
 // String devuelve una representación en cadena de la lista.
@@ -0,0 +1,122 @@
+package perfecthash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// magic identifica el formato binario de la tabla.
+var magic = [4]byte{'P', 'H', 'T', 'B'}
+
+// version es la versión del formato binario de la tabla.
+const version = 1
+
+// ErrInvalidFormat se devuelve al deserializar datos que no corresponden a una
+// tabla válida.
+var ErrInvalidFormat = errors.New("perfecthash: formato inválido")
+
+// MarshalBinary serializa la tabla en un arreglo de bytes, que puede cargarse
+// con UnmarshalBinary o Load sin reconstruir la función de hash.
+//
+// El formato es: magic, versión, semilla, cantidad de buckets, cantidad de
+// claves, los desplazamientos, las claves (cada una precedida por su longitud)
+// y por último los valores codificados con encoding/gob. Los enteros se
+// codifican en little endian.
+func (t *Table[V]) MarshalBinary() ([]byte, error) {
+	data := append([]byte{}, magic[:]...)
+	data = append(data, version)
+	data = binary.LittleEndian.AppendUint64(data, t.seed)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(t.displacements)))
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(t.keys)))
+	for _, d := range t.displacements {
+		data = binary.LittleEndian.AppendUint32(data, d)
+	}
+	for _, key := range t.keys {
+		data = binary.AppendUvarint(data, uint64(len(key)))
+		data = append(data, key...)
+	}
+
+	buf := bytes.NewBuffer(data)
+	if err := gob.NewEncoder(buf).Encode(t.values); err != nil {
+		return nil, fmt.Errorf("perfecthash: no se pudieron codificar los valores: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary reemplaza el contenido de la tabla por el serializado en
+// data.
+//
+// Devuelve ErrInvalidFormat si los datos no son válidos, en cuyo caso la tabla
+// no se modifica.
+func (t *Table[V]) UnmarshalBinary(data []byte) error {
+	const headerSize = 4 + 1 + 8 + 4 + 4
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return ErrInvalidFormat
+	}
+	if data[4] != version {
+		return fmt.Errorf("%w: versión %d no soportada", ErrInvalidFormat, data[4])
+	}
+	seed := binary.LittleEndian.Uint64(data[5:])
+	numBuckets := int(binary.LittleEndian.Uint32(data[13:]))
+	n := int(binary.LittleEndian.Uint32(data[17:]))
+	data = data[headerSize:]
+	if numBuckets == 0 || len(data) < 4*numBuckets {
+		return ErrInvalidFormat
+	}
+
+	displacements := make([]uint32, numBuckets)
+	for i := range displacements {
+		displacements[i] = binary.LittleEndian.Uint32(data[4*i:])
+	}
+	data = data[4*numBuckets:]
+
+	// Cada clave ocupa al menos un byte, su longitud, por lo que n se acota
+	// con los datos restantes antes de reservar memoria.
+	if n > len(data) {
+		return ErrInvalidFormat
+	}
+	keys := make([]string, n)
+	for i := range keys {
+		length, size := binary.Uvarint(data)
+		if size <= 0 || uint64(len(data)-size) < length {
+			return ErrInvalidFormat
+		}
+		keys[i] = string(data[size : size+int(length)])
+		data = data[size+int(length):]
+	}
+
+	var values []V
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	// encoding/gob decodifica un arreglo vacío como nil.
+	if len(values) != n {
+		return ErrInvalidFormat
+	}
+	if values == nil {
+		values = make([]V, 0)
+	}
+
+	// La tabla se reemplaza sólo si cada clave está en la posición que le
+	// asigna el hash.
+	loaded := Table[V]{seed: seed, displacements: displacements, keys: keys, values: values}
+	for i, key := range keys {
+		if loaded.index(key) != uint64(i) {
+			return ErrInvalidFormat
+		}
+	}
+	*t = loaded
+	return nil
+}
+
+// Load crea una tabla a partir de los datos serializados con MarshalBinary.
+func Load[V any](data []byte) (*Table[V], error) {
+	t := &Table[V]{}
+	if err := t.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,123 @@
+package perfecthash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	keys := make([]string, 1000)
+	values := make([][]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("clave-%d", i)
+		values[i] = []string{fmt.Sprint(i), "valor"}
+	}
+	table, err := Build(keys, values)
+	require.NoError(t, err)
+
+	data, err := table.MarshalBinary()
+	require.NoError(t, err)
+
+	loaded, err := Load[[]string](data)
+	require.NoError(t, err)
+	assert.Equal(t, table.Size(), loaded.Size())
+	for i, key := range keys {
+		v, ok := loaded.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, values[i], v)
+	}
+	assert.False(t, loaded.Contains("ausente"))
+}
+
+func TestMarshalBinaryVacia(t *testing.T) {
+	table, err := Build[int](nil, nil)
+	require.NoError(t, err)
+
+	data, err := table.MarshalBinary()
+	require.NoError(t, err)
+
+	loaded, err := Load[int](data)
+	require.NoError(t, err)
+	assert.True(t, loaded.IsEmpty())
+}
+
+func TestUnmarshalBinaryInvalido(t *testing.T) {
+	table, err := Build([]string{"a", "b"}, []int{1, 2})
+	require.NoError(t, err)
+	data, err := table.MarshalBinary()
+	require.NoError(t, err)
+
+	_, err = Load[int](nil)
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	_, err = Load[int](data[:25])
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	_, err = Load[string](data)
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestUnmarshalBinaryCantidadDeClavesExcesiva(t *testing.T) {
+	table, err := Build([]string{"a"}, []int{1})
+	require.NoError(t, err)
+	data, err := table.MarshalBinary()
+	require.NoError(t, err)
+
+	// El encabezado declara muchas más claves que bytes hay en los datos.
+	binary.LittleEndian.PutUint32(data[17:], math.MaxUint32)
+	_, err = Load[int](data)
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestUnmarshalBinaryCantidadDeValores(t *testing.T) {
+	withValues := func(table *Table[int], values []int) []byte {
+		data, err := table.MarshalBinary()
+		require.NoError(t, err)
+		// Reemplazamos los valores, que están al final de los datos.
+		var empty bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&empty).Encode(table.values))
+		buf := bytes.NewBuffer(data[:len(data)-empty.Len()])
+		require.NoError(t, gob.NewEncoder(buf).Encode(values))
+		return buf.Bytes()
+	}
+	empty, err := Build[int](nil, nil)
+	require.NoError(t, err)
+	table, err := Build([]string{"a", "b"}, []int{1, 2})
+	require.NoError(t, err)
+
+	_, err = Load[int](withValues(empty, []int{1}))
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	_, err = Load[int](withValues(table, []int{1}))
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	_, err = Load[int](withValues(table, []int{1, 2, 3}))
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	loaded, err := Load[int](withValues(table, []int{3, 4}))
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), loaded.Size())
+}
+
+func TestUnmarshalBinaryInvalidoNoModificaLaTabla(t *testing.T) {
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("clave-%d", i)
+	}
+	other, err := Build(keys, make([]int, len(keys)))
+	require.NoError(t, err)
+	data, err := other.MarshalBinary()
+	require.NoError(t, err)
+	// Cambiamos la semilla: las claves dejan de estar en su posición.
+	binary.LittleEndian.PutUint64(data[5:], other.seed+1)
+
+	table, err := Build([]string{"a", "b"}, []int{1, 2})
+	require.NoError(t, err)
+	assert.ErrorIs(t, table.UnmarshalBinary(data), ErrInvalidFormat)
+	assert.Equal(t, uint(2), table.Size())
+	v, ok := table.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
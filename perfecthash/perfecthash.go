@@ -0,0 +1,257 @@
+// perfecthash proporciona una tabla de hash estática construida con una
+// función de hash perfecta mínima, utilizando el algoritmo CHD (Compress, Hash
+// and Displace, de Belazzougui, Botelho y Dietzfelbinger, 2009).
+//
+// La tabla se construye una única vez a partir de un conjunto fijo de claves y
+// luego sólo admite consultas. Cada clave se ubica en una posición distinta
+// del arreglo de n elementos, por lo que toda búsqueda realiza exactamente un
+// acceso al arreglo sin colisiones.
+package perfecthash
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// bucketLoad es la cantidad promedio de claves por bucket. Valores más altos
+// reducen el espacio ocupado por los desplazamientos pero hacen más lenta la
+// construcción.
+const bucketLoad = 4
+
+// minDisplacements es la cantidad mínima de desplazamientos que se prueban
+// para un bucket antes de reintentar la construcción con otra semilla.
+const minDisplacements = 1 << 20
+
+// displacementsPerKey es la cantidad de desplazamientos que se prueban por
+// cada clave de la tabla. Los últimos buckets en ubicarse tienen pocas
+// posiciones libres, y con n claves y una sola posición libre se necesitan en
+// promedio n desplazamientos para acertarle, por lo que el límite crece con n.
+const displacementsPerKey = 32
+
+// maxAttempts es la cantidad máxima de semillas que se prueban antes de
+// abandonar la construcción.
+const maxAttempts = 16
+
+// Reader es el conjunto de métodos de consulta de una tabla de hash. Lo
+// implementan tanto Table como hashtable.HashTable con claves string.
+type Reader[V any] interface {
+	Get(key string) (V, bool)
+	Keys() []string
+	Values() []V
+	Size() uint
+	IsEmpty() bool
+}
+
+var (
+	_ Reader[int] = (*Table[int])(nil)
+	_ Reader[int] = (*hashtable.HashTable[string, int])(nil)
+)
+
+// Table es una tabla de hash de sólo lectura construida con una función de
+// hash perfecta mínima.
+type Table[V any] struct {
+	// seed es la semilla utilizada para asignar claves a buckets.
+	seed uint64
+	// displacements contiene, para cada bucket, la semilla con la que se
+	// calcula la posición de sus claves.
+	displacements []uint32
+	// keys contiene las claves, cada una en la posición que le asigna el hash.
+	keys []string
+	// values contiene los valores, en la misma posición que su clave.
+	values []V
+}
+
+// Build construye una tabla a partir de las claves y sus valores asociados,
+// donde values[i] es el valor de keys[i].
+//
+// Devuelve un error si las cantidades no coinciden, si hay claves repetidas o
+// si no se encontró una función de hash perfecta.
+//
+// - Los desplazamientos se almacenan en 32 bits, por lo que con más de
+// math.MaxUint32/displacementsPerKey claves (unos 134 millones) la
+// construcción puede fallar.
+func Build[V any](keys []string, values []V) (*Table[V], error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("perfecthash: %d claves y %d valores", len(keys), len(values))
+	}
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("perfecthash: clave repetida %q", key)
+		}
+		seen[key] = struct{}{}
+	}
+
+	for attempt := range uint64(maxAttempts) {
+		t := &Table[V]{seed: attempt}
+		if t.build(keys, values) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("perfecthash: no se encontró una función de hash perfecta para %d claves", len(keys))
+}
+
+// BuildFromMap construye una tabla con los pares clave-valor del mapa.
+func BuildFromMap[V any](m map[string]V) (*Table[V], error) {
+	keys := make([]string, 0, len(m))
+	values := make([]V, 0, len(m))
+	for key, value := range m {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return Build(keys, values)
+}
+
+// BuildFromHashTable construye una tabla con los pares clave-valor de una
+// tabla de hash.
+func BuildFromHashTable[V any](ht *hashtable.HashTable[string, V]) (*Table[V], error) {
+	keys := ht.Keys()
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		values[i], _ = ht.Get(key)
+	}
+	return Build(keys, values)
+}
+
+// Get devuelve el valor asociado a la clave dada y true para indicar que
+// encontró la clave buscada.
+//
+// - Si la clave no existe, devuelve false y un valor nulo.
+func (t *Table[V]) Get(key string) (V, bool) {
+	if len(t.keys) == 0 {
+		var zeroValue V
+		return zeroValue, false
+	}
+	index := t.index(key)
+	if t.keys[index] != key {
+		var zeroValue V
+		return zeroValue, false
+	}
+	return t.values[index], true
+}
+
+// Contains devuelve true si la clave pertenece a la tabla.
+func (t *Table[V]) Contains(key string) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Keys devuelve una lista de todas las claves de la tabla.
+func (t *Table[V]) Keys() []string {
+	return slices.Clone(t.keys)
+}
+
+// Values devuelve una lista de todos los valores de la tabla.
+func (t *Table[V]) Values() []V {
+	return slices.Clone(t.values)
+}
+
+// Size devuelve el número de elementos de la tabla.
+func (t *Table[V]) Size() uint {
+	return uint(len(t.keys))
+}
+
+// IsEmpty devuelve true si la tabla está vacía, false en caso contrario.
+func (t *Table[V]) IsEmpty() bool {
+	return len(t.keys) == 0
+}
+
+// String devuelve una representación en cadena de la tabla.
+func (t *Table[V]) String() string {
+	result := "{"
+	for i, key := range t.keys {
+		if i > 0 {
+			result += ", "
+		}
+		result += fmt.Sprintf("%v: %v", key, t.values[i])
+	}
+	return result + "}"
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// bucket devuelve el bucket al que pertenece la clave.
+func (t *Table[V]) bucket(key string) uint64 {
+	return hashing.String64(key, t.seed) % uint64(len(t.displacements))
+}
+
+// position devuelve la posición de la clave para un desplazamiento dado.
+func (t *Table[V]) position(key string, displacement uint32) uint64 {
+	return hashing.String64(key, ^uint64(displacement)) % uint64(len(t.keys))
+}
+
+// index devuelve la posición de la clave en la tabla.
+func (t *Table[V]) index(key string) uint64 {
+	return t.position(key, t.displacements[t.bucket(key)])
+}
+
+// maxDisplacements devuelve la cantidad de desplazamientos que se prueban para
+// cada bucket de una tabla de n claves.
+func maxDisplacements(n int) uint32 {
+	return uint32(min(max(minDisplacements, displacementsPerKey*uint64(n)), math.MaxUint32))
+}
+
+// build intenta construir la tabla con la semilla actual. Devuelve false si
+// algún bucket no pudo ubicarse.
+func (t *Table[V]) build(keys []string, values []V) bool {
+	n := len(keys)
+	t.displacements = make([]uint32, max(1, (n+bucketLoad-1)/bucketLoad))
+	t.keys = make([]string, n)
+	t.values = make([]V, n)
+	if n == 0 {
+		return true
+	}
+
+	// Agrupamos las claves (por su posición en keys) en buckets.
+	buckets := make([][]int, len(t.displacements))
+	for i, key := range keys {
+		b := t.bucket(key)
+		buckets[b] = append(buckets[b], i)
+	}
+	order := make([]int, len(buckets))
+	for i := range order {
+		order[i] = i
+	}
+	// Ubicamos primero los buckets más grandes, que son los más difíciles.
+	slices.SortStableFunc(order, func(a, b int) int {
+		return len(buckets[b]) - len(buckets[a])
+	})
+
+	limit := maxDisplacements(n)
+	used := make([]bool, n)
+	positions := make([]uint64, 0, bucketLoad)
+	for _, b := range order {
+		if len(buckets[b]) == 0 {
+			break
+		}
+		placed := false
+		for d := uint32(0); d < limit && !placed; d++ {
+			positions = positions[:0]
+			placed = true
+			for _, i := range buckets[b] {
+				p := t.position(keys[i], d)
+				if used[p] || slices.Contains(positions, p) {
+					placed = false
+					break
+				}
+				positions = append(positions, p)
+			}
+			if placed {
+				t.displacements[b] = d
+				for j, i := range buckets[b] {
+					used[positions[j]] = true
+					t.keys[positions[j]] = keys[i]
+					t.values[positions[j]] = values[i]
+				}
+			}
+		}
+		if !placed {
+			return false
+		}
+	}
+	return true
+}
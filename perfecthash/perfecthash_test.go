@@ -0,0 +1,115 @@
+package perfecthash
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	table, err := Build([]string{"Dungeons", "Dragons"}, []string{"Calabozos", "Dragones"})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(2), table.Size())
+	assert.False(t, table.IsEmpty())
+	v, ok := table.Get("Dungeons")
+	assert.True(t, ok)
+	assert.Equal(t, "Calabozos", v)
+	v, ok = table.Get("Dragons")
+	assert.True(t, ok)
+	assert.Equal(t, "Dragones", v)
+	v, ok = table.Get("Dwarf")
+	assert.False(t, ok)
+	assert.Equal(t, "", v)
+}
+
+func TestBuildVacia(t *testing.T) {
+	table, err := Build[int](nil, nil)
+	require.NoError(t, err)
+
+	assert.True(t, table.IsEmpty())
+	assert.False(t, table.Contains("hola"))
+	assert.Equal(t, "{}", table.String())
+}
+
+func TestBuildErrores(t *testing.T) {
+	_, err := Build([]string{"a", "b"}, []int{1})
+	assert.Error(t, err)
+
+	_, err = Build([]string{"a", "b", "a"}, []int{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestBuildEsMinimaYPerfecta(t *testing.T) {
+	n := 20000
+	keys := make([]string, n)
+	values := make([]int, n)
+	for i := range n {
+		keys[i] = fmt.Sprintf("palabra-%d", i)
+		values[i] = i
+	}
+
+	table, err := Build(keys, values)
+	require.NoError(t, err)
+
+	// Cada clave ocupa una posición distinta del arreglo de n elementos.
+	assert.Equal(t, n, len(table.keys))
+	seen := make(map[uint64]bool, n)
+	for i, key := range keys {
+		index := table.index(key)
+		assert.False(t, seen[index])
+		seen[index] = true
+
+		v, ok := table.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+	for i := range 1000 {
+		assert.False(t, table.Contains(fmt.Sprintf("ausente-%d", i)))
+	}
+}
+
+func TestMaxDisplacementsCreceConLasClaves(t *testing.T) {
+	assert.Equal(t, uint32(minDisplacements), maxDisplacements(10))
+	// Con una sola posición libre entre n, se necesitan en promedio n
+	// desplazamientos para ubicar un bucket.
+	assert.Greater(t, maxDisplacements(1<<22), uint32(1<<22))
+	assert.Equal(t, uint32(math.MaxUint32), maxDisplacements(math.MaxUint32))
+}
+
+func TestBuildFromMap(t *testing.T) {
+	table, err := BuildFromMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, table.Keys())
+	assert.ElementsMatch(t, []int{1, 2, 3}, table.Values())
+}
+
+func TestBuildFromHashTable(t *testing.T) {
+	ht := hashtable.NewHashTable[string, int](0, 0)
+	ht.Put("uno", 1)
+	ht.Put("dos", 2)
+
+	table, err := BuildFromHashTable(ht)
+	require.NoError(t, err)
+
+	var reader Reader[int] = table
+	assert.Equal(t, ht.Size(), reader.Size())
+	for _, key := range ht.Keys() {
+		expected, _ := ht.Get(key)
+		v, ok := reader.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, expected, v)
+	}
+}
+
+func TestTableString(t *testing.T) {
+	table, err := Build([]string{"a"}, []int{1})
+	require.NoError(t, err)
+
+	assert.Equal(t, "{a: 1}", table.String())
+}
@@ -0,0 +1,184 @@
+// sketch proporciona estructuras para aproximar frecuencias de elementos en
+// flujos de datos no acotados utilizando memoria constante: un sketch
+// Count-Min y un contador de elementos más frecuentes (Space-Saving).
+package sketch
+
+import (
+	"fmt"
+	"math"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashing"
+)
+
+// CountMin es un sketch Count-Min: una matriz de depth filas por width columnas
+// de contadores, donde cada fila utiliza una función de hash independiente.
+//
+// La estimación de un elemento nunca es menor a su frecuencia real, y con
+// probabilidad 1 - e^(-depth) la sobreestima en a lo sumo (e / width) * N,
+// siendo N la suma de todas las frecuencias agregadas.
+type CountMin struct {
+	// counters contiene los contadores de todas las filas, de a width por fila.
+	counters []uint64
+	// width es la cantidad de columnas de cada fila.
+	width uint
+	// depth es la cantidad de filas.
+	depth uint
+	// conservative indica si se utiliza actualización conservadora.
+	conservative bool
+	// total es la suma de todas las frecuencias agregadas.
+	total uint64
+}
+
+// NewCountMin crea un nuevo sketch Count-Min con las dimensiones especificadas.
+//
+// Con actualización conservadora, al agregar un elemento sólo se incrementan
+// los contadores que quedarían por debajo de la nueva estimación, lo que reduce
+// la sobreestimación.
+//
+// - Si el ancho es igual a 0, se establece en 2048.
+//
+// - Si la profundidad es igual a 0, se establece en 5.
+func NewCountMin(width, depth uint, conservative bool) *CountMin {
+	if width == 0 {
+		width = 2048
+	}
+	if depth == 0 {
+		depth = 5
+	}
+	return &CountMin{
+		counters:     make([]uint64, width*depth),
+		width:        width,
+		depth:        depth,
+		conservative: conservative,
+	}
+}
+
+// NewCountMinWithError crea un nuevo sketch Count-Min cuyas estimaciones
+// exceden la frecuencia real en a lo sumo epsilon * N con probabilidad
+// 1 - delta.
+//
+// - Si epsilon no está en el intervalo (0, 1), se establece en 0.001.
+//
+// - Si delta no está en el intervalo (0, 1), se establece en 0.01.
+func NewCountMinWithError(epsilon, delta float64, conservative bool) *CountMin {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	width := uint(math.Ceil(math.E / epsilon))
+	depth := uint(math.Ceil(math.Log(1 / delta)))
+	return NewCountMin(width, depth, conservative)
+}
+
+// Add suma count a la frecuencia del elemento.
+func (cm *CountMin) Add(item []byte, count uint64) {
+	cm.add(hashing.Sum64(item, 0), count)
+}
+
+// AddString suma count a la frecuencia de la cadena.
+func (cm *CountMin) AddString(item string, count uint64) {
+	cm.add(hashing.String64(item, 0), count)
+}
+
+// Estimate devuelve la frecuencia estimada del elemento.
+func (cm *CountMin) Estimate(item []byte) uint64 {
+	return cm.estimate(hashing.Sum64(item, 0))
+}
+
+// EstimateString devuelve la frecuencia estimada de la cadena.
+func (cm *CountMin) EstimateString(item string) uint64 {
+	return cm.estimate(hashing.String64(item, 0))
+}
+
+// Merge suma los contadores de otro sketch al actual, de modo que el resultado
+// estima las frecuencias de la unión de ambos flujos.
+//
+// Devuelve un error si los sketches tienen distintas dimensiones o si uno
+// utiliza actualización conservadora y el otro no, ya que sus contadores no
+// son comparables.
+func (cm *CountMin) Merge(other *CountMin) error {
+	if cm.width != other.width || cm.depth != other.depth {
+		return fmt.Errorf("sketch: no se pueden combinar sketches de %dx%d y %dx%d",
+			cm.depth, cm.width, other.depth, other.width)
+	}
+	if cm.conservative != other.conservative {
+		return fmt.Errorf("sketch: no se pueden combinar sketches con y sin actualización conservadora")
+	}
+	for i, c := range other.counters {
+		cm.counters[i] += c
+	}
+	cm.total += other.total
+	return nil
+}
+
+// Total devuelve la suma de todas las frecuencias agregadas.
+func (cm *CountMin) Total() uint64 {
+	return cm.total
+}
+
+// Epsilon devuelve el error relativo al total garantizado por el sketch.
+func (cm *CountMin) Epsilon() float64 {
+	return math.E / float64(cm.width)
+}
+
+// Confidence devuelve la probabilidad de que una estimación respete la cota
+// de error.
+func (cm *CountMin) Confidence() float64 {
+	return 1 - math.Exp(-float64(cm.depth))
+}
+
+// ErrorBound devuelve la sobreestimación máxima, en valor absoluto, que tienen
+// las estimaciones con probabilidad Confidence().
+func (cm *CountMin) ErrorBound() uint64 {
+	return uint64(math.Ceil(cm.Epsilon() * float64(cm.total)))
+}
+
+// Clear pone en cero todos los contadores del sketch.
+func (cm *CountMin) Clear() {
+	clear(cm.counters)
+	cm.total = 0
+}
+
+// String devuelve una representación en cadena del sketch.
+func (cm *CountMin) String() string {
+	return fmt.Sprintf("CountMin: {width: %d, depth: %d, total: %d}",
+		cm.width, cm.depth, cm.total)
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// index devuelve la posición del contador de la fila row para el hash dado.
+//
+// Los índices de cada fila se derivan de dos hashes con la técnica de
+// Kirsch-Mitzenmacher: h1 + row * h2.
+func (cm *CountMin) index(hash uint64, row uint) uint {
+	h1, h2 := hash, hashing.Mix64(hash)|1
+	return row*cm.width + uint((h1+uint64(row)*h2)%uint64(cm.width))
+}
+
+// add suma count a los contadores correspondientes al hash dado.
+func (cm *CountMin) add(hash uint64, count uint64) {
+	cm.total += count
+	if !cm.conservative {
+		for row := range cm.depth {
+			cm.counters[cm.index(hash, row)] += count
+		}
+		return
+	}
+	target := cm.estimate(hash) + count
+	for row := range cm.depth {
+		i := cm.index(hash, row)
+		cm.counters[i] = max(cm.counters[i], target)
+	}
+}
+
+// estimate devuelve el mínimo de los contadores correspondientes al hash dado.
+func (cm *CountMin) estimate(hash uint64) uint64 {
+	result := uint64(math.MaxUint64)
+	for row := range cm.depth {
+		result = min(result, cm.counters[cm.index(hash, row)])
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package sketch
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zipf devuelve n cadenas con distribución de Zipf sobre un universo de
+// distinct valores, junto con sus frecuencias exactas.
+func zipf(n int, distinct uint64, seed uint64) ([]string, map[string]uint64) {
+	r := rand.New(rand.NewPCG(seed, seed))
+	z := rand.NewZipf(r, 1.2, 1, distinct-1)
+	data := make([]string, n)
+	exact := make(map[string]uint64)
+	for i := range data {
+		data[i] = fmt.Sprintf("palabra-%d", z.Uint64())
+		exact[data[i]]++
+	}
+	return data, exact
+}
+
+func TestNewCountMin(t *testing.T) {
+	cm := NewCountMin(0, 0, false)
+
+	assert.Equal(t, "CountMin: {width: 2048, depth: 5, total: 0}", cm.String())
+	assert.Equal(t, uint64(0), cm.EstimateString("hola"))
+}
+
+func TestNewCountMinWithError(t *testing.T) {
+	cm := NewCountMinWithError(0.01, 0.01, false)
+
+	assert.LessOrEqual(t, cm.Epsilon(), 0.01)
+	assert.GreaterOrEqual(t, cm.Confidence(), 0.99)
+}
+
+func TestCountMinNuncaSubestima(t *testing.T) {
+	for _, conservative := range []bool{false, true} {
+		cm := NewCountMin(200, 4, conservative)
+		data, exact := zipf(50000, 5000, 1)
+		for _, item := range data {
+			cm.AddString(item, 1)
+		}
+
+		assert.Equal(t, uint64(50000), cm.Total())
+		for item, count := range exact {
+			assert.GreaterOrEqual(t, cm.EstimateString(item), count)
+		}
+	}
+}
+
+func TestCountMinCotaDeError(t *testing.T) {
+	cm := NewCountMinWithError(0.001, 0.01, false)
+	data, exact := zipf(100000, 10000, 2)
+	for _, item := range data {
+		cm.Add([]byte(item), 1)
+	}
+
+	exceeded := 0
+	for item, count := range exact {
+		if cm.Estimate([]byte(item))-count > cm.ErrorBound() {
+			exceeded++
+		}
+	}
+	assert.LessOrEqual(t, float64(exceeded)/float64(len(exact)), 1-cm.Confidence())
+}
+
+func TestCountMinConservadorEsMasExacto(t *testing.T) {
+	plain := NewCountMin(100, 4, false)
+	conservative := NewCountMin(100, 4, true)
+	data, exact := zipf(50000, 5000, 3)
+	for _, item := range data {
+		plain.AddString(item, 1)
+		conservative.AddString(item, 1)
+	}
+
+	var plainErr, conservativeErr uint64
+	for item, count := range exact {
+		plainErr += plain.EstimateString(item) - count
+		conservativeErr += conservative.EstimateString(item) - count
+	}
+	assert.Less(t, conservativeErr, plainErr)
+}
+
+func TestCountMinMerge(t *testing.T) {
+	a := NewCountMin(500, 4, false)
+	b := NewCountMin(500, 4, false)
+	a.AddString("hola", 3)
+	b.AddString("hola", 4)
+	b.AddString("chau", 1)
+
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, uint64(8), a.Total())
+	assert.GreaterOrEqual(t, a.EstimateString("hola"), uint64(7))
+	assert.GreaterOrEqual(t, a.EstimateString("chau"), uint64(1))
+}
+
+func TestCountMinMergeDimensionesDistintas(t *testing.T) {
+	assert.Error(t, NewCountMin(100, 4, false).Merge(NewCountMin(200, 4, false)))
+}
+
+func TestCountMinMergeConservadorDistinto(t *testing.T) {
+	assert.Error(t, NewCountMin(100, 4, false).Merge(NewCountMin(100, 4, true)))
+	assert.NoError(t, NewCountMin(100, 4, true).Merge(NewCountMin(100, 4, true)))
+}
+
+func TestCountMinClear(t *testing.T) {
+	cm := NewCountMin(100, 4, true)
+	cm.AddString("hola", 10)
+
+	cm.Clear()
+	assert.Equal(t, uint64(0), cm.Total())
+	assert.Equal(t, uint64(0), cm.EstimateString("hola"))
+}
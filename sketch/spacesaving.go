@@ -0,0 +1,232 @@
+package sketch
+
+import (
+	"cmp"
+	"container/heap"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Item es un elemento seguido por SpaceSaving junto con su frecuencia
+// estimada.
+type Item struct {
+	// Value es el elemento.
+	Value string
+	// Count es la frecuencia estimada, que nunca es menor a la real.
+	Count uint64
+	// Error es la sobreestimación máxima de Count.
+	Error uint64
+}
+
+// SpaceSaving sigue los k elementos más frecuentes de un flujo con el
+// algoritmo Space-Saving (Metwally, Agrawal y El Abbadi, 2005).
+//
+// Todo elemento con frecuencia real mayor a N / k está entre los seguidos,
+// siendo N la suma de todas las frecuencias agregadas.
+type SpaceSaving struct {
+	// capacity es la cantidad máxima de elementos seguidos.
+	capacity int
+	// items asocia cada elemento seguido con su posición en el heap.
+	items map[string]*counter
+	// heap es un heap de mínimos de los contadores, ordenado por frecuencia.
+	heap counterHeap
+	// total es la suma de todas las frecuencias agregadas.
+	total uint64
+}
+
+// NewSpaceSaving crea un nuevo contador que sigue a lo sumo k elementos.
+//
+// - Si k es menor o igual a 0, se establece en 100.
+func NewSpaceSaving(k int) *SpaceSaving {
+	if k <= 0 {
+		k = 100
+	}
+	return &SpaceSaving{
+		capacity: k,
+		items:    make(map[string]*counter, k),
+		heap:     make(counterHeap, 0, k),
+	}
+}
+
+// Add suma count a la frecuencia del elemento.
+//
+// - Si el elemento no está seguido y no hay lugar, reemplaza al elemento de
+// menor frecuencia y hereda su frecuencia como error.
+func (s *SpaceSaving) Add(item string, count uint64) {
+	s.total += count
+	if c, ok := s.items[item]; ok {
+		c.item.Count += count
+		heap.Fix(&s.heap, c.index)
+		return
+	}
+	if len(s.heap) < s.capacity {
+		c := &counter{item: Item{Value: item, Count: count}}
+		s.items[item] = c
+		heap.Push(&s.heap, c)
+		return
+	}
+	c := s.heap[0]
+	delete(s.items, c.item.Value)
+	c.item = Item{Value: item, Count: c.item.Count + count, Error: c.item.Count}
+	s.items[item] = c
+	heap.Fix(&s.heap, 0)
+}
+
+// Estimate devuelve la frecuencia estimada del elemento y su error máximo.
+//
+// - Si el elemento no está seguido, su frecuencia real es a lo sumo la menor
+// frecuencia seguida, que se devuelve como error.
+func (s *SpaceSaving) Estimate(item string) (uint64, uint64) {
+	if c, ok := s.items[item]; ok {
+		return c.item.Count, c.item.Error
+	}
+	return 0, s.minCount()
+}
+
+// TopK devuelve los n elementos de mayor frecuencia estimada, ordenados de
+// mayor a menor.
+//
+// - Si n es mayor a la cantidad de elementos seguidos, devuelve todos.
+//
+// - Si n es menor o igual a 0, devuelve un slice vacío.
+func (s *SpaceSaving) TopK(n int) []Item {
+	if n <= 0 {
+		return []Item{}
+	}
+	items := make([]Item, 0, len(s.heap))
+	for _, c := range s.heap {
+		items = append(items, c.item)
+	}
+	slices.SortFunc(items, byCount)
+	return items[:min(n, len(items))]
+}
+
+// Merge combina los elementos de otro contador en el actual (Agarwal et al.,
+// 2012). Los elementos que no están seguidos en uno de los contadores suman la
+// menor frecuencia de ese contador, y se conservan los k de mayor frecuencia.
+func (s *SpaceSaving) Merge(other *SpaceSaving) {
+	minSelf, minOther := s.minCount(), other.minCount()
+	merged := make(map[string]Item, len(s.items)+len(other.items))
+	for value, c := range s.items {
+		item := c.item
+		if o, ok := other.items[value]; ok {
+			item.Count += o.item.Count
+			item.Error += o.item.Error
+		} else {
+			item.Count += minOther
+			item.Error += minOther
+		}
+		merged[value] = item
+	}
+	for value, o := range other.items {
+		if _, ok := s.items[value]; !ok {
+			item := o.item
+			item.Count += minSelf
+			item.Error += minSelf
+			merged[value] = item
+		}
+	}
+
+	items := make([]Item, 0, len(merged))
+	for _, item := range merged {
+		items = append(items, item)
+	}
+	slices.SortFunc(items, byCount)
+
+	total := s.total + other.total
+	s.Clear()
+	s.total = total
+	for _, item := range items[:min(s.capacity, len(items))] {
+		c := &counter{item: item}
+		s.items[item.Value] = c
+		heap.Push(&s.heap, c)
+	}
+}
+
+// Total devuelve la suma de todas las frecuencias agregadas.
+func (s *SpaceSaving) Total() uint64 {
+	return s.total
+}
+
+// ErrorBound devuelve la sobreestimación máxima de cualquier elemento, que es
+// a lo sumo N / k.
+func (s *SpaceSaving) ErrorBound() uint64 {
+	return s.minCount()
+}
+
+// Size devuelve la cantidad de elementos seguidos.
+func (s *SpaceSaving) Size() int {
+	return len(s.heap)
+}
+
+// Clear elimina todos los elementos seguidos.
+func (s *SpaceSaving) Clear() {
+	s.items = make(map[string]*counter, s.capacity)
+	s.heap = s.heap[:0]
+	s.total = 0
+}
+
+// String devuelve una representación en cadena de los elementos seguidos,
+// ordenados de mayor a menor frecuencia.
+func (s *SpaceSaving) String() string {
+	result := "SpaceSaving: {"
+	for i, item := range s.TopK(s.capacity) {
+		if i > 0 {
+			result += ", "
+		}
+		result += fmt.Sprintf("%v: %v", item.Value, item.Count)
+	}
+	return result + "}"
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// minCount devuelve la menor frecuencia seguida si no hay lugar para nuevos
+// elementos, o 0 en caso contrario.
+func (s *SpaceSaving) minCount() uint64 {
+	if len(s.heap) < s.capacity {
+		return 0
+	}
+	return s.heap[0].item.Count
+}
+
+// byCount ordena elementos de mayor a menor frecuencia y, ante igual
+// frecuencia, alfabéticamente.
+func byCount(a, b Item) int {
+	if c := cmp.Compare(b.Count, a.Count); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Value, b.Value)
+}
+
+// counter es un elemento seguido junto con su posición en el heap.
+type counter struct {
+	item  Item
+	index int
+}
+
+// counterHeap implementa heap.Interface como un heap de mínimos por frecuencia.
+type counterHeap []*counter
+
+func (h counterHeap) Len() int           { return len(h) }
+func (h counterHeap) Less(i, j int) bool { return h[i].item.Count < h[j].item.Count }
+
+func (h counterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *counterHeap) Push(x any) {
+	c := x.(*counter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *counterHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,97 @@
+package sketch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpaceSaving(t *testing.T) {
+	s := NewSpaceSaving(0)
+
+	assert.Equal(t, 0, s.Size())
+	assert.Empty(t, s.TopK(10))
+	assert.Equal(t, "SpaceSaving: {}", s.String())
+}
+
+func TestSpaceSavingExactoConLugar(t *testing.T) {
+	s := NewSpaceSaving(10)
+	for _, w := range []string{"a", "b", "a", "c", "a", "b"} {
+		s.Add(w, 1)
+	}
+
+	assert.Equal(t, []Item{{"a", 3, 0}, {"b", 2, 0}, {"c", 1, 0}}, s.TopK(10))
+	assert.Equal(t, []Item{{"a", 3, 0}}, s.TopK(1))
+	assert.Empty(t, s.TopK(0))
+	assert.Empty(t, s.TopK(-1))
+	assert.Equal(t, "SpaceSaving: {a: 3, b: 2, c: 1}", s.String())
+	assert.Equal(t, uint64(0), s.ErrorBound())
+}
+
+func TestSpaceSavingReemplazaAlMenor(t *testing.T) {
+	s := NewSpaceSaving(2)
+	s.Add("a", 5)
+	s.Add("b", 2)
+	s.Add("c", 1)
+
+	count, err := s.Estimate("c")
+	assert.Equal(t, uint64(3), count)
+	assert.Equal(t, uint64(2), err)
+	count, err = s.Estimate("b")
+	assert.Equal(t, uint64(0), count)
+	assert.Equal(t, uint64(3), err)
+}
+
+func TestSpaceSavingEncuentraFrecuentes(t *testing.T) {
+	data, exact := zipf(100000, 10000, 4)
+	s := NewSpaceSaving(50)
+	for _, item := range data {
+		s.Add(item, 1)
+	}
+
+	assert.Equal(t, 50, s.Size())
+	assert.LessOrEqual(t, s.ErrorBound(), s.Total()/50)
+	for item, count := range exact {
+		if count > s.Total()/50 {
+			estimate, err := s.Estimate(item)
+			require.NotZero(t, estimate, item)
+			assert.GreaterOrEqual(t, estimate, count)
+			assert.LessOrEqual(t, estimate-err, count)
+		}
+	}
+}
+
+func TestSpaceSavingMerge(t *testing.T) {
+	data, exact := zipf(100000, 10000, 5)
+	a := NewSpaceSaving(50)
+	b := NewSpaceSaving(50)
+	for i, item := range data {
+		if i%2 == 0 {
+			a.Add(item, 1)
+		} else {
+			b.Add(item, 1)
+		}
+	}
+
+	a.Merge(b)
+	assert.Equal(t, uint64(100000), a.Total())
+	assert.Equal(t, 50, a.Size())
+	for item, count := range exact {
+		if count > a.Total()/50 {
+			estimate, err := a.Estimate(item)
+			require.NotZero(t, estimate, item)
+			assert.GreaterOrEqual(t, estimate, count)
+			assert.LessOrEqual(t, estimate-err, count)
+		}
+	}
+}
+
+func TestSpaceSavingClear(t *testing.T) {
+	s := NewSpaceSaving(2)
+	s.Add("a", 1)
+
+	s.Clear()
+	assert.Equal(t, 0, s.Size())
+	assert.Equal(t, uint64(0), s.Total())
+}
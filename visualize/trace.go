@@ -0,0 +1,96 @@
+package visualize
+
+import (
+	"fmt"
+	"strings"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// Frame es el estado del arreglo de la tabla luego de una operación.
+type Frame[K any, V any] struct {
+	// Number es el número de cuadro; el cuadro 0 es el estado inicial.
+	Number int
+	// Operation describe la operación realizada, por ejemplo `Put(uno, 1)`.
+	Operation string
+	// Result es el valor devuelto por la operación.
+	Result bool
+	// Slots es el estado del arreglo luego de la operación.
+	Slots []hashtable.Slot[K, V]
+}
+
+// ASCII devuelve el cuadro en texto, precedido por su número y operación.
+func (f Frame[K, V]) ASCII() string {
+	return fmt.Sprintf("#%d %s\n%s", f.Number, f.Operation, ASCII(f.Slots))
+}
+
+// DOT devuelve el cuadro en el lenguaje DOT de Graphviz.
+func (f Frame[K, V]) DOT() string {
+	return DOT(f.Slots)
+}
+
+// SVG devuelve el cuadro como un documento SVG independiente.
+func (f Frame[K, V]) SVG() string {
+	return SVG(f.Slots)
+}
+
+// Trace registra, paso a paso, cómo cambia el arreglo de una tabla a medida
+// que se le aplican operaciones.
+//
+// Uso:
+//
+//	trace := visualize.NewTrace(hashtable.NewHashTable[string, int](7, 0.75))
+//	trace.Put("uno", 1)
+//	trace.Remove("uno")
+//	fmt.Print(trace.ASCII())
+type Trace[K any, V any] struct {
+	table  *hashtable.HashTable[K, V]
+	frames []Frame[K, V]
+}
+
+// NewTrace crea un registro para la tabla dada, cuyo primer cuadro es el
+// estado actual de la tabla.
+func NewTrace[K any, V any](table *hashtable.HashTable[K, V]) *Trace[K, V] {
+	t := &Trace[K, V]{table: table}
+	t.record("inicial", true)
+	return t
+}
+
+// Put agrega el par clave-valor a la tabla y registra un cuadro.
+func (t *Trace[K, V]) Put(key K, value V) bool {
+	result := t.table.Put(key, value)
+	t.record(fmt.Sprintf("Put(%v, %v)", key, value), result)
+	return result
+}
+
+// Remove elimina la clave de la tabla y registra un cuadro.
+func (t *Trace[K, V]) Remove(key K) bool {
+	result := t.table.Remove(key)
+	t.record(fmt.Sprintf("Remove(%v)", key), result)
+	return result
+}
+
+// Frames devuelve los cuadros registrados, en orden.
+func (t *Trace[K, V]) Frames() []Frame[K, V] {
+	return t.frames
+}
+
+// ASCII devuelve todos los cuadros en texto, separados por una línea en
+// blanco.
+func (t *Trace[K, V]) ASCII() string {
+	parts := make([]string, len(t.frames))
+	for i, f := range t.frames {
+		parts[i] = f.ASCII()
+	}
+	return strings.Join(parts, "\n")
+}
+
+// record agrega un cuadro con el estado actual de la tabla.
+func (t *Trace[K, V]) record(operation string, result bool) {
+	t.frames = append(t.frames, Frame[K, V]{
+		Number:    len(t.frames),
+		Operation: operation,
+		Result:    result,
+		Slots:     t.table.Slots(),
+	})
+}
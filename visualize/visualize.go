@@ -0,0 +1,176 @@
+// visualize genera representaciones gráficas del arreglo de una tabla de hash
+// cerrada (hashtable.HashTable), pensadas para explicar cómo funciona la
+// prueba lineal: qué posiciones están ocupadas, vacías o eliminadas, cuál es la
+// posición inicial de cada clave y qué secuencia de prueba se recorre para
+// llegar a ella.
+//
+// Se ofrecen tres formatos: texto (ASCII), Graphviz DOT y SVG. Además, Trace
+// permite registrar una secuencia de operaciones Put y Remove como cuadros
+// numerados.
+package visualize
+
+import (
+	"fmt"
+	"strings"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// ASCII devuelve una representación en texto del arreglo, con una línea por
+// posición.
+//
+// Por ejemplo:
+//
+//	[ 0] ·
+//	[ 1] uno: 1
+//	[ 2] dos: 2  ← 1 (+1)
+//	[ 3] ✗
+//
+// Las posiciones vacías se muestran con "·" y las eliminadas con "✗". Para las
+// claves desplazadas se indica su posición inicial y la cantidad de
+// posiciones recorridas.
+func ASCII[K any, V any](slots []hashtable.Slot[K, V]) string {
+	capacity := uint(len(slots))
+	width := len(fmt.Sprint(max(1, capacity) - 1))
+	labels := make([]string, len(slots))
+	labelWidth := 0
+	for i, slot := range slots {
+		labels[i] = label(slot)
+		labelWidth = max(labelWidth, len([]rune(labels[i])))
+	}
+
+	var b strings.Builder
+	for i, slot := range slots {
+		fmt.Fprintf(&b, "[%*d] ", width, slot.Index)
+		if d := slot.Displacement(capacity); slot.State == hashtable.SlotOccupied && d > 0 {
+			padding := labelWidth - len([]rune(labels[i]))
+			fmt.Fprintf(&b, "%s%s  ← %d (+%d)", labels[i], strings.Repeat(" ", padding), slot.Home, d)
+		} else {
+			b.WriteString(labels[i])
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// DOT devuelve una representación del arreglo en el lenguaje DOT de Graphviz.
+//
+// Cada posición es un nodo, y las posiciones se muestran en una fila. Para
+// cada clave desplazada se dibuja una flecha punteada desde su posición
+// inicial hasta la posición que ocupa, que representa la secuencia de prueba.
+//
+// Puede convertirse en imagen con, por ejemplo:
+//
+//	dot -Tpng tabla.dot -o tabla.png
+func DOT[K any, V any](slots []hashtable.Slot[K, V]) string {
+	capacity := uint(len(slots))
+	var b strings.Builder
+	b.WriteString("digraph hashtable {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=filled, fontname=\"monospace\"];\n")
+	for _, slot := range slots {
+		fmt.Fprintf(&b, "\ts%d [label=\"%d\\n%s\", fillcolor=\"%s\"];\n",
+			slot.Index, slot.Index, escapeDOT(label(slot)), fillColor(slot.State))
+	}
+	if len(slots) > 1 {
+		b.WriteString("\t{ rank=same; ")
+		for _, slot := range slots {
+			fmt.Fprintf(&b, "s%d; ", slot.Index)
+		}
+		b.WriteString("}\n\t")
+		for i, slot := range slots {
+			if i > 0 {
+				b.WriteString(" -> ")
+			}
+			fmt.Fprintf(&b, "s%d", slot.Index)
+		}
+		b.WriteString(" [style=invis];\n")
+	}
+	for _, slot := range slots {
+		if slot.State == hashtable.SlotOccupied && slot.Displacement(capacity) > 0 {
+			fmt.Fprintf(&b, "\ts%d -> s%d [style=dashed, color=\"#d62728\", constraint=false, label=\"+%d\"];\n",
+				slot.Home, slot.Index, slot.Displacement(capacity))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Dimensiones de las celdas en SVG.
+const (
+	cellWidth  = 100
+	cellHeight = 50
+	margin     = 20
+	arcSpace   = 60
+)
+
+// SVG devuelve un documento SVG independiente con el arreglo dibujado como una
+// fila de celdas. Las claves desplazadas se unen a su posición inicial con un
+// arco que representa la secuencia de prueba.
+func SVG[K any, V any](slots []hashtable.Slot[K, V]) string {
+	capacity := uint(len(slots))
+	width := 2*margin + cellWidth*len(slots)
+	height := 2*margin + arcSpace + cellHeight + 20
+	top := margin + arcSpace
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" font-family=\"monospace\" font-size=\"12\">\n",
+		width, height, width, height)
+	b.WriteString("<defs><marker id=\"arrow\" markerWidth=\"8\" markerHeight=\"8\" refX=\"6\" refY=\"4\" orient=\"auto\">" +
+		"<path d=\"M0,0 L8,4 L0,8 z\" fill=\"#d62728\"/></marker></defs>\n")
+	for i, slot := range slots {
+		x := margin + i*cellWidth
+		fmt.Fprintf(&b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\" stroke=\"#333333\"/>\n",
+			x, top, cellWidth, cellHeight, fillColor(slot.State))
+		fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">%s</text>\n",
+			x+cellWidth/2, top+cellHeight/2+4, escapeXML(label(slot)))
+		fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\" fill=\"#666666\">%d</text>\n",
+			x+cellWidth/2, top+cellHeight+15, slot.Index)
+	}
+	for _, slot := range slots {
+		if slot.State != hashtable.SlotOccupied || slot.Displacement(capacity) == 0 {
+			continue
+		}
+		from := margin + int(slot.Home)*cellWidth + cellWidth/2
+		to := margin + int(slot.Index)*cellWidth + cellWidth/2
+		peak := top - arcSpace*min(int(slot.Displacement(capacity)), 4)/4
+		fmt.Fprintf(&b, "<path d=\"M%d,%d Q%d,%d %d,%d\" fill=\"none\" stroke=\"#d62728\" stroke-dasharray=\"4\" marker-end=\"url(#arrow)\"/>\n",
+			from, top, (from+to)/2, peak, to, top)
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// label devuelve el texto que se muestra para una posición.
+func label[K any, V any](slot hashtable.Slot[K, V]) string {
+	switch slot.State {
+	case hashtable.SlotOccupied:
+		return fmt.Sprintf("%v: %v", slot.Key, slot.Value)
+	case hashtable.SlotDeleted:
+		return "✗"
+	}
+	return "·"
+}
+
+// fillColor devuelve el color de fondo para un estado.
+func fillColor(state hashtable.SlotState) string {
+	switch state {
+	case hashtable.SlotOccupied:
+		return "#cfe8ff"
+	case hashtable.SlotDeleted:
+		return "#e0e0e0"
+	}
+	return "#ffffff"
+}
+
+// escapeDOT escapa un texto para incluirlo entre comillas en DOT.
+func escapeDOT(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// escapeXML escapa un texto para incluirlo en un documento SVG.
+func escapeXML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
@@ -0,0 +1,115 @@
+package visualize
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// fixture arma un arreglo de cuatro posiciones con una clave en su posición
+// inicial, una clave desplazada, una eliminada y una vacía.
+func fixture() []hashtable.Slot[string, int] {
+	return []hashtable.Slot[string, int]{
+		{Index: 0, State: hashtable.SlotEmpty},
+		{Index: 1, State: hashtable.SlotOccupied, Key: "uno", Value: 1, Home: 1},
+		{Index: 2, State: hashtable.SlotOccupied, Key: "dos", Value: 2, Home: 1},
+		{Index: 3, State: hashtable.SlotDeleted, Home: 3},
+	}
+}
+
+func TestASCII(t *testing.T) {
+	expected := "[0] ·\n" +
+		"[1] uno: 1\n" +
+		"[2] dos: 2  ← 1 (+1)\n" +
+		"[3] ✗\n"
+	assert.Equal(t, expected, ASCII(fixture()))
+}
+
+func TestASCIIVacio(t *testing.T) {
+	assert.Equal(t, "", ASCII[string, int](nil))
+}
+
+func TestDOT(t *testing.T) {
+	dot := DOT(fixture())
+
+	assert.True(t, strings.HasPrefix(dot, "digraph hashtable {"))
+	assert.Contains(t, dot, `s0 [label="0\n·", fillcolor="#ffffff"];`)
+	assert.Contains(t, dot, `s1 [label="1\nuno: 1", fillcolor="#cfe8ff"];`)
+	assert.Contains(t, dot, `s3 [label="3\n✗", fillcolor="#e0e0e0"];`)
+	assert.Contains(t, dot, `s1 -> s2 [style=dashed`)
+	assert.NotContains(t, dot, `s1 -> s1 [style=dashed`)
+}
+
+func TestDOTEscapa(t *testing.T) {
+	slots := []hashtable.Slot[string, string]{
+		{Index: 0, State: hashtable.SlotOccupied, Key: `a"b`, Value: `c\d`},
+	}
+
+	assert.Contains(t, DOT(slots), `label="0\na\"b: c\\d"`)
+}
+
+func TestSVG(t *testing.T) {
+	svg := SVG(fixture())
+
+	assert.Equal(t, 4, strings.Count(svg, "<rect "))
+	assert.Equal(t, 1, strings.Count(svg, "stroke-dasharray"))
+	assert.Contains(t, svg, ">dos: 2</text>")
+	require.NoError(t, xml.Unmarshal([]byte(svg), new(struct{})))
+}
+
+func TestSVGEscapa(t *testing.T) {
+	slots := []hashtable.Slot[string, string]{
+		{Index: 0, State: hashtable.SlotOccupied, Key: "<a&b>", Value: "x"},
+	}
+	svg := SVG(slots)
+
+	assert.Contains(t, svg, "&lt;a&amp;b&gt;: x")
+	require.NoError(t, xml.Unmarshal([]byte(svg), new(struct{})))
+}
+
+func TestTrace(t *testing.T) {
+	ht := hashtable.NewHashTable[string, int](7, 0.75)
+	trace := NewTrace(ht)
+
+	assert.True(t, trace.Put("uno", 1))
+	assert.True(t, trace.Put("dos", 2))
+	assert.True(t, trace.Remove("uno"))
+	assert.False(t, trace.Remove("tres"))
+
+	frames := trace.Frames()
+	require.Len(t, frames, 5)
+	for i, frame := range frames {
+		assert.Equal(t, i, frame.Number)
+		assert.Len(t, frame.Slots, 7)
+	}
+	assert.Equal(t, "inicial", frames[0].Operation)
+	assert.Equal(t, "Put(uno, 1)", frames[1].Operation)
+	assert.Equal(t, "Remove(uno)", frames[3].Operation)
+	assert.False(t, frames[4].Result)
+
+	assert.Equal(t, 0, count(frames[0].Slots, hashtable.SlotOccupied))
+	assert.Equal(t, 2, count(frames[2].Slots, hashtable.SlotOccupied))
+	assert.Equal(t, 1, count(frames[3].Slots, hashtable.SlotOccupied))
+	assert.Equal(t, 1, count(frames[3].Slots, hashtable.SlotDeleted))
+
+	text := trace.ASCII()
+	assert.Contains(t, text, "#0 inicial\n")
+	assert.Contains(t, text, "#2 Put(dos, 2)\n")
+	assert.Contains(t, frames[1].DOT(), "uno: 1")
+	assert.Contains(t, frames[1].SVG(), "uno: 1")
+}
+
+func count(slots []hashtable.Slot[string, int], state hashtable.SlotState) int {
+	n := 0
+	for _, slot := range slots {
+		if slot.State == state {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,182 @@
+// weakcache proporciona una caché cuyos valores se referencian de forma débil:
+// la caché no impide que el recolector de basura libere un valor, y cuando lo
+// hace, su entrada se elimina automáticamente.
+//
+// Sirve para memorizar objetos grandes, por ejemplo el resultado de procesar
+// un archivo, mientras el resto del programa los siga usando, sin retenerlos
+// en memoria cuando ya nadie los necesita.
+package weakcache
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"weak"
+
+	"untref-ayp2/guia-conjuntos-hashes-diccionarios/hashtable"
+)
+
+// entry es una entrada de la caché.
+type entry[V any] struct {
+	// pointer es la referencia débil al valor.
+	pointer weak.Pointer[V]
+	// cleanup es la función que elimina la entrada cuando se libera el valor.
+	cleanup runtime.Cleanup
+}
+
+// Cache es una caché con claves de cualquier tipo comparable y valores
+// referenciados de forma débil. Las entradas se almacenan en una
+// hashtable.HashTable.
+//
+// Una entrada desaparece de la caché cuando su valor deja de ser alcanzable
+// desde el resto del programa y el recolector de basura lo libera. Mientras
+// tanto, Get puede seguir devolviéndolo.
+//
+// Es segura para uso concurrente, ya que las entradas se eliminan desde la
+// goroutine que ejecuta las funciones de limpieza del runtime.
+//
+// Los valores muy pequeños y sin punteros pueden compartir su bloque de
+// memoria con otros objetos, por lo que su entrada puede tardar más en
+// eliminarse.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries *hashtable.HashTable[K, entry[V]]
+}
+
+// New crea una nueva caché vacía.
+func New[K comparable, V any]() *Cache[K, V] {
+	return &Cache[K, V]{entries: hashtable.NewHashTable[K, entry[V]](0, 0)}
+}
+
+// Put asocia el valor a la clave. Si la clave ya existe, reemplaza el valor.
+//
+// La caché no retiene el valor: la entrada se elimina cuando el valor es
+// liberado por el recolector de basura.
+//
+// - Si el valor es nil, elimina la clave.
+func (c *Cache[K, V]) Put(key K, value *V) {
+	if value == nil {
+		c.Remove(key)
+		return
+	}
+	pointer := weak.Make(value)
+	// La función de limpieza no debe referenciar al valor, ya que eso
+	// impediría liberarlo.
+	cleanup := runtime.AddCleanup(value, c.evict, evictArg[K, V]{key: key, pointer: pointer})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.entries.Get(key); ok {
+		old.cleanup.Stop()
+	}
+	c.entries.Put(key, entry[V]{pointer: pointer, cleanup: cleanup})
+}
+
+// Get devuelve el valor asociado a la clave y true, si la clave existe y su
+// valor no fue liberado.
+//
+// - Si la clave no existe o su valor fue liberado, devuelve nil y false.
+func (c *Cache[K, V]) Get(key K) (*V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	value := e.pointer.Value()
+	if value == nil {
+		// El valor fue liberado pero la limpieza aún no se ejecutó.
+		c.entries.Remove(key)
+		return nil, false
+	}
+	return value, true
+}
+
+// GetOrCompute devuelve el valor asociado a la clave. Si la clave no existe o
+// su valor fue liberado, lo calcula con compute y lo agrega a la caché.
+//
+// compute se ejecuta sin bloquear la caché, por lo que dos llamadas
+// concurrentes con la misma clave pueden calcular el valor dos veces; la caché
+// conserva el último.
+//
+// - Si compute devuelve un error, no se agrega nada y se devuelve el error.
+func (c *Cache[K, V]) GetOrCompute(key K, compute func() (*V, error)) (*V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+	value, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	c.Put(key, value)
+	return value, nil
+}
+
+// Remove elimina la clave de la caché.
+//
+// Devuelve true si se eliminó la clave, false si no existe.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries.Get(key)
+	if !ok {
+		return false
+	}
+	e.cleanup.Stop()
+	return c.entries.Remove(key)
+}
+
+// Size devuelve la cantidad de entradas de la caché, incluidas las de valores
+// ya liberados cuya limpieza aún no se ejecutó.
+func (c *Cache[K, V]) Size() uint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Size()
+}
+
+// Keys devuelve las claves cuyos valores no fueron liberados.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.entries.Size())
+	for _, key := range c.entries.Keys() {
+		if e, _ := c.entries.Get(key); e.pointer.Value() != nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Clear elimina todas las entradas de la caché.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range c.entries.Values() {
+		e.cleanup.Stop()
+	}
+	c.entries.Clear()
+}
+
+// String devuelve una representación en cadena de la caché, con las claves
+// cuyos valores no fueron liberados.
+func (c *Cache[K, V]) String() string {
+	return fmt.Sprintf("Cache: %v", c.Keys())
+}
+
+// Funciones privadas //////////////////////////////////////////////////////////
+
+// evictArg es el argumento de la función de limpieza de un valor.
+type evictArg[K comparable, V any] struct {
+	key     K
+	pointer weak.Pointer[V]
+}
+
+// evict elimina la entrada de la clave, si todavía corresponde al valor
+// liberado.
+func (c *Cache[K, V]) evict(arg evictArg[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries.Get(arg.key); ok && e.pointer == arg.pointer {
+		c.entries.Remove(arg.key)
+	}
+}
@@ -0,0 +1,144 @@
+package weakcache
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// document es un valor grande, para que el recolector de basura lo libere de
+// forma individual.
+type document struct {
+	name string
+	data [1024]byte
+}
+
+// collected ejecuta el recolector de basura hasta que la caché quede con la
+// cantidad de entradas indicada.
+func collected[K comparable, V any](t *testing.T, c *Cache[K, V], size uint) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		return c.Size() == size
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCachePutGet(t *testing.T) {
+	c := New[string, document]()
+	doc := &document{name: "uno"}
+	c.Put("uno", doc)
+
+	got, ok := c.Get("uno")
+	assert.True(t, ok)
+	assert.Same(t, doc, got)
+	_, ok = c.Get("dos")
+	assert.False(t, ok)
+	assert.Equal(t, uint(1), c.Size())
+	assert.Equal(t, []string{"uno"}, c.Keys())
+	assert.Equal(t, "Cache: [uno]", c.String())
+	runtime.KeepAlive(doc)
+}
+
+func TestCacheEliminaValoresLiberados(t *testing.T) {
+	c := New[string, document]()
+	kept := &document{name: "retenido"}
+	c.Put("retenido", kept)
+	c.Put("liberado", &document{name: "liberado"})
+
+	collected(t, c, 1)
+	_, ok := c.Get("liberado")
+	assert.False(t, ok)
+	got, ok := c.Get("retenido")
+	assert.True(t, ok)
+	assert.Same(t, kept, got)
+	runtime.KeepAlive(kept)
+}
+
+func TestCacheReemplazo(t *testing.T) {
+	c := New[string, document]()
+	c.Put("clave", &document{name: "viejo"})
+	current := &document{name: "nuevo"}
+	c.Put("clave", current)
+
+	// Liberar el valor reemplazado no elimina la entrada actual.
+	for range 5 {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	got, ok := c.Get("clave")
+	require.True(t, ok)
+	assert.Equal(t, "nuevo", got.name)
+	runtime.KeepAlive(current)
+}
+
+func TestCacheRemove(t *testing.T) {
+	c := New[string, document]()
+	doc := &document{}
+	c.Put("uno", doc)
+
+	assert.True(t, c.Remove("uno"))
+	assert.False(t, c.Remove("uno"))
+	_, ok := c.Get("uno")
+	assert.False(t, ok)
+
+	c.Put("dos", doc)
+	c.Put("dos", nil)
+	assert.Equal(t, uint(0), c.Size())
+	runtime.KeepAlive(doc)
+}
+
+func TestCacheClear(t *testing.T) {
+	c := New[int, document]()
+	docs := []*document{{}, {}, {}}
+	for i, doc := range docs {
+		c.Put(i, doc)
+	}
+
+	c.Clear()
+	assert.Equal(t, uint(0), c.Size())
+	runtime.KeepAlive(docs)
+}
+
+func TestCacheGetOrCompute(t *testing.T) {
+	c := New[string, document]()
+	calls := 0
+	compute := func() (*document, error) {
+		calls++
+		return &document{name: "calculado"}, nil
+	}
+
+	first, err := c.GetOrCompute("clave", compute)
+	require.NoError(t, err)
+	second, err := c.GetOrCompute("clave", compute)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, calls)
+	runtime.KeepAlive(first)
+
+	fail := errors.New("falla")
+	_, err = c.GetOrCompute("otra", func() (*document, error) { return nil, fail })
+	assert.ErrorIs(t, err, fail)
+	_, ok := c.Get("otra")
+	assert.False(t, ok)
+}
+
+func TestCacheGetOrComputeRecalculaTrasLiberar(t *testing.T) {
+	c := New[string, document]()
+	calls := 0
+	compute := func() (*document, error) {
+		calls++
+		return &document{}, nil
+	}
+
+	_, err := c.GetOrCompute("clave", compute)
+	require.NoError(t, err)
+	collected(t, c, 0)
+	doc, err := c.GetOrCompute("clave", compute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	runtime.KeepAlive(doc)
+}